@@ -3,6 +3,7 @@ module crypto-news-intelligence
 go 1.24.0
 
 require (
+	github.com/PuerkitoBio/goquery v1.8.0
 	github.com/chromedp/chromedp v0.12.1
 	github.com/joho/godotenv v1.5.1
 	github.com/mmcdole/gofeed v1.3.0
@@ -10,7 +11,6 @@ require (
 )
 
 require (
-	github.com/PuerkitoBio/goquery v1.8.0 // indirect
 	github.com/andybalholm/cascadia v1.3.1 // indirect
 	github.com/chromedp/cdproto v0.0.0-20250724212937-08a3db8b4327 // indirect
 	github.com/chromedp/sysutil v1.1.0 // indirect
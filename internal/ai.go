@@ -2,6 +2,7 @@ package internal
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -37,10 +38,12 @@ type ChatResponse struct {
 	} `json:"error"`
 }
 
-// Internal state for key rotation
+// Internal state for key rotation. Pollers, the ingest handlers and the AI
+// worker all call the LLM concurrently, so the index is guarded by keyMu.
 var (
 	aiKeys            []string
 	currentKeyIndex   = 0
+	keyMu             sync.Mutex
 	aiKeysOnce        sync.Once
 )
 
@@ -65,6 +68,8 @@ func GetNextKey() string {
 	if len(keys) == 0 {
 		return ""
 	}
+	keyMu.Lock()
+	defer keyMu.Unlock()
 	key := keys[currentKeyIndex]
 	currentKeyIndex = (currentKeyIndex + 1) % len(keys)
 	return key
@@ -72,13 +77,29 @@ func GetNextKey() string {
 
 // AnalyzeNewsAI calls AI to analyze the news
 func AnalyzeNewsAI(item NewsItem) (string, string, string, string) {
-	searchQuery := fmt.Sprintf("%s %s crypto news", item.Title, item.Asset)
+	searchQuery := fmt.Sprintf("%s %s crypto news", item.EnglishTitle(), item.Asset)
 	fmt.Printf("🔍 Serper Searching: %s...\n", searchQuery)
 	searchResults := SearchWeb(searchQuery)
 
+	excerpt := item.Body
+	if excerpt == "" {
+		excerpt = item.Description
+	}
+	if excerpt == "" {
+		excerpt = "(headline only)"
+	}
+
+	headline := item.Title
+	if item.TitleEN != "" {
+		headline = fmt.Sprintf("%s (English: %s)", item.Title, item.TitleEN)
+	}
+
 	prompt := fmt.Sprintf(`
 Analyze this crypto news headline: "%s" (Asset: %s).
 
+Article Excerpt:
+%s
+
 Verified Web Search Context (Live Data):
 %s
 
@@ -89,8 +110,19 @@ Respond in JSON:
   "coin": "The specific coin symbol (e.g. DOT, SOL, BTC) or 'GENERAL'.",
   "signal": "One of: STRONG_BUY, BUY, WAIT, CAUTION, SELL, STRONG_SELL"
 }
-`, item.Title, item.Asset, searchResults)
+`, headline, item.Asset, truncate(excerpt, 1500), searchResults)
 
+	raw, err := callLLM(context.Background(), prompt+" Respond in JSON only.", true)
+	if err != nil {
+		return "AI Exhausted", "All keys failed.", "", "WAIT"
+	}
+	return parseRawResponse(raw)
+}
+
+// callLLM sends a prompt to the configured model, rotating through AI_KEYS
+// until one works or ctx ends, and returns the raw text of the reply.
+// jsonMode asks Ollama for JSON-only output.
+func callLLM(ctx context.Context, prompt string, jsonMode bool) (string, error) {
 	ollamaUrl := os.Getenv("OLLAMA_URL")
 	if ollamaUrl == "" {
 		ollamaUrl = "https://ollama.com/api/generate"
@@ -103,46 +135,51 @@ Respond in JSON:
 
 	ollamaPayload := map[string]interface{}{
 		"model":  model,
-		"prompt": prompt + " Respond in JSON only.",
+		"prompt": prompt,
 		"stream": false,
-		"format": "json",
+	}
+	if jsonMode {
+		ollamaPayload["format"] = "json"
 	}
 	ollamaJson, _ := json.Marshal(ollamaPayload)
 
 	keys := getAIKeys()
 	for i := 0; i < len(keys); i++ {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
 		key := GetNextKey()
 		
 		client := &http.Client{Timeout: 15 * time.Second}
-		req, _ := http.NewRequest("POST", ollamaUrl, bytes.NewBuffer(ollamaJson))
+		req, _ := http.NewRequestWithContext(ctx, "POST", ollamaUrl, bytes.NewBuffer(ollamaJson))
 		req.Header.Set("Content-Type", "application/json")
 		req.Header.Set("Authorization", "Bearer "+key)
 
 		resp, err := client.Do(req)
 		if err == nil && resp.StatusCode == 200 {
-			defer resp.Body.Close()
 			body, _ := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
 
 			var result ChatResponse
 			if err := json.Unmarshal(body, &result); err == nil && len(result.Choices) > 0 {
-				return parseRawResponse(result.Choices[0].Message.Content)
+				return result.Choices[0].Message.Content, nil
 			}
 
 			var ollamaRes struct {
 				Response string `json:"response"`
 			}
 			if err := json.Unmarshal(body, &ollamaRes); err == nil && ollamaRes.Response != "" {
-				return parseRawResponse(ollamaRes.Response)
+				return ollamaRes.Response, nil
 			}
 			
-			return parseRawResponse(string(body))
+			return string(body), nil
 		}
 		if resp != nil {
 			resp.Body.Close()
 		}
 	}
 
-	return "AI Exhausted", "All keys failed.", "", "WAIT"
+	return "", fmt.Errorf("all AI keys failed")
 }
 
 func parseRawResponse(raw string) (string, string, string, string) {
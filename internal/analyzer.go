@@ -1,103 +1,164 @@
 package internal
 
 import (
+	"math"
+	"sort"
 	"strings"
 )
 
-// AnalyzeNews performs rule-based analysis on a news item
+// AnalyzeNews performs rule-based analysis on a news item,
+// using the word lists and weights of the current lexicon
 func AnalyzeNews(item *NewsItem) {
-	title := strings.ToLower(item.Title)
+	lex := CurrentLexicon()
+	title := Tokenize(item.EnglishTitle()) // Keyword lists are English-only
 
 	// Default values
 	item.Scope = "ASSET"
 	item.Asset = "ALT"
-	item.Impact = 0.3
+	item.Impact = lex.DefaultImpact
 	item.Sentiment = 0.0
 
 	// 1. Market-wide detection
-	marketKeywords := []string{"fed", "cpi", "sec", "etf", "regulation", "inflation", "interest rate", "macro", "economy"}
-	for _, kw := range marketKeywords {
-		if strings.Contains(title, kw) {
-			item.Scope = "MARKET"
-			item.Asset = "ALL"
-			item.Impact = 0.7
-			break
-		}
+	if title.HasAny(lex.MarketKeywords) {
+		item.Scope = "MARKET"
+		item.Asset = "ALL"
+		item.Impact = lex.MarketImpact
 	}
 
-	// 2. Asset-specific detection - Improved with word boundaries
-	assets := map[string][]string{
-		"BTC":  {"btc", "bitcoin"},
-		"ETH":  {"eth", "ethereum", "ether"},
-		"SOL":  {"sol", "solana"},
-		"BNB":  {"bnb", "binance"},
-		"XRP":  {"xrp", "ripple"},
-		"ADA":  {"ada", "cardano"},
-		"DOGE": {"doge", "dogecoin"},
-		"APT":  {"apt", "aptos"},
+	// 2. Asset-specific detection - every asset mentioned, ranked by relevance
+	item.Assets = tagAssets(lex, item, title)
+	if len(item.Assets) > 0 {
+		item.Asset = item.Assets[0].Symbol
 	}
 
-	for asset, keywords := range assets {
-		for _, kw := range keywords {
-			if containsWord(title, kw) {
-				item.Asset = asset
-				break
-			}
-		}
-		if item.Asset != "ALT" {
-			break
-		}
+	// The source knows the asset better than keyword matching (e.g. go-ethereum -> ETH)
+	if item.AssetHint != "" {
+		item.Scope = "ASSET"
 	}
 
 	// 3. Keyword Impact Table (Sentiment & Event detection)
-	bullishKeywords := []string{"surges", "jumps", "breakout", "adds", "record high", "moon", "rally", "gains", "bullish", "outperform", "upgrade", "listing", "listed", "partnership", "collaboration", "legalizes", "adoption", "pushes", "above"}
-	bearishKeywords := []string{"loses", "falls", "exit", "withdrawn", "bloodbath", "crash", "bearish", "drop", "down", "delisting", "delisted", "hack", "exploit", "compromised", "selloff", "backlash", "left", "outflow", "ban", "restrict", "lose", "losing"}
-
-	for _, kw := range bullishKeywords {
-		if containsWord(title, kw) {
-			item.Sentiment += 0.3
+	// Negators nearby flip a keyword, hedges and questions weaken it
+	mods := scanModifiers(&lex.Modifiers, title)
+	var notes sentimentNotes
+	hedged := false
+	titleSentiment := 0.0
+
+	// Inflections of one word ("listing" / "listed") only count once
+	counted := make(map[string]bool)
+	for _, kw := range lex.Keywords {
+		key := stemKey(kw.Term)
+		if counted[key] {
+			continue
+		}
+		found, negator, hedge := mods.match(kw.Term)
+		if !found {
+			continue
+		}
+		counted[key] = true
+
+		switch {
+		case negator != "":
+			titleSentiment += kw.Sentiment * lex.Modifiers.NegationFactor
+			notes.add("negated %q (%s)", kw.Term, negator)
+		case hedge != "":
+			titleSentiment += kw.Sentiment * lex.Modifiers.HedgeFactor
+			notes.add("hedged %q (%s)", kw.Term, hedge)
+			hedged = true
+		default:
+			titleSentiment += kw.Sentiment
+		}
+		// A negated keyword didn't happen, so it can't raise impact
+		if negator == "" && kw.Impact > item.Impact {
+			item.Impact = kw.Impact
 		}
 	}
 
-	for _, kw := range bearishKeywords {
-		if containsWord(title, kw) {
-			item.Sentiment -= 0.3
+	// Lexicons before version 2 have no modifier weights
+	question := lex.Version >= 2 && isQuestion(title.Raw)
+	if question {
+		titleSentiment *= lex.Modifiers.QuestionFactor
+		notes.add("question headline")
+	}
+	item.Sentiment += titleSentiment
+
+	// The summary carries less weight than the headline
+	if item.Description != "" {
+		summary := scanModifiers(&lex.Modifiers, Tokenize(item.Description))
+		counted := make(map[string]bool)
+		for _, kw := range lex.Keywords {
+			key := stemKey(kw.Term)
+			if counted[key] {
+				continue
+			}
+			found, negator, hedge := summary.match(kw.Term)
+			if !found {
+				continue
+			}
+			counted[key] = true
+
+			weight := kw.Sentiment * lex.SummaryWeight
+			switch {
+			case negator != "":
+				weight *= lex.Modifiers.NegationFactor
+			case hedge != "":
+				weight *= lex.Modifiers.HedgeFactor
+			}
+			item.Sentiment += weight
 		}
 	}
 
-	// 4. Specific High Impact Events
-	if strings.Contains(title, "listing") || strings.Contains(title, "listed") {
-		item.Impact = 0.8
-	}
-	if strings.Contains(title, "delisting") || strings.Contains(title, "delisted") {
-		item.Impact = 0.9
+	// 4. Specific High Impact Events ("denies listing" is no listing)
+	var event *EventRule
+	for i := range lex.Events {
+		ev := &lex.Events[i]
+		found, negator, hedge := mods.matchAny(ev.Terms)
+		if !found {
+			continue
+		}
+		if negator != "" {
+			notes.add("no %s (%s)", strings.ToLower(ev.Type), negator)
+			continue
+		}
+		if hedge != "" {
+			hedged = true
+		}
+		if event == nil || ev.Impact > event.Impact {
+			event = ev
+		}
 	}
-	if strings.Contains(title, "hack") || strings.Contains(title, "exploit") || strings.Contains(title, "compromised") {
-		item.Impact = 1.0
+	if event != nil {
+		if event.Impact > item.Impact {
+			item.Impact = event.Impact
+		}
+		if item.Category == "" {
+			item.Category = event.Type
+		}
 	}
 
 	// 5. Price Action Noise Filter (Option 2)
-	priceKeywords := []string{"surges", "jumps", "climbs", "pops", "falls", "drops", "slumps"}
-	isPriceActionOnly := false
-	for _, kw := range priceKeywords {
-		if strings.Contains(title, kw) {
-			isPriceActionOnly = true
-			break
-		}
+	if title.HasAny(lex.PriceNoise.Terms) && !title.HasAny(lex.PriceNoise.Unless) {
+		item.Impact = lex.PriceNoise.Impact // Just price noise, lower impact
 	}
 
-	if isPriceActionOnly {
-		eventKeywords := []string{"listing", "delisting", "hack", "exploit", "partnership", "fed", "cpi", "sec", "etf", "regulation", "legalizes", "approves"}
-		hasEvent := false
-		for _, kw := range eventKeywords {
-			if strings.Contains(title, kw) {
-				hasEvent = true
-				break
-			}
-		}
+	// Unconfirmed news moves markets less
+	if hedged || question {
+		item.Impact *= lex.Modifiers.ImpactFactor
+	}
+	item.SentimentNote = notes.String()
 
-		if !hasEvent {
-			item.Impact = 0.1 // Just price noise, lower impact
+	// 6. Protocol releases: security fixes force node upgrades and hint at live bugs
+	if item.Category == CategoryRelease && isSecurityRelease(lex, item) {
+		item.Category = CategorySecurity
+	}
+	switch item.Category {
+	case CategorySecurity:
+		item.Impact = 1.0
+		if item.Sentiment > -0.3 {
+			item.Sentiment = -0.3
+		}
+	case CategoryRelease:
+		if item.Impact < 0.5 {
+			item.Impact = 0.5
 		}
 	}
 
@@ -106,28 +167,70 @@ func AnalyzeNews(item *NewsItem) {
 	if item.Sentiment < -1.0 { item.Sentiment = -1.0 }
 }
 
-func containsWord(s, word string) bool {
-	index := strings.Index(s, word)
-	if index == -1 {
-		return false
-	}
+// tagAssets scores every known asset (lexicon + registry) the item mentions.
+// A headline mention counts most (more the earlier it appears), the summary
+// and body add a little; the source's AssetHint is always fully relevant.
+// Ties keep lexicon / registry order, so the primary asset is deterministic.
+func tagAssets(lex *Lexicon, item *NewsItem, title *TokenizedText) []AssetTag {
+	summary := Tokenize(item.Description)
+	body := Tokenize(truncate(item.Body, 2000))
+
+	matchers := assetMatchers(lex)
+	titleHits := assetMentions(matchers, title)
+	summaryHits := assetMentions(matchers, summary)
+	bodyHits := assetMentions(matchers, body)
+
+	var tags []AssetTag
+	for k, asset := range matchers {
+		relevance := 0.0
+		if asset.symbol == strings.ToUpper(item.AssetHint) {
+			relevance = 1.0
+		}
+
+		if hit := titleHits[k]; hit.at >= 0 {
+			relevance += 0.6 + 0.3*(1-float64(hit.at)/float64(len(title.Raw)))
+			// "Binance Will List Pepe (PEPE)" is about PEPE, not the exchange
+			if hit.explicit {
+				relevance += 0.3
+			}
+		}
+		if summaryHits[k].at >= 0 {
+			relevance += 0.25
+		}
+		if bodyHits[k].at >= 0 {
+			relevance += 0.15
+		}
 
-	// Check prev char
-	if index > 0 {
-		prev := s[index-1]
-		if (prev >= 'a' && prev <= 'z') || (prev >= '0' && prev <= '9') {
-			return false
+		if relevance > 0 {
+			tags = append(tags, AssetTag{Symbol: asset.symbol, Relevance: math.Min(1, math.Round(relevance*100)/100)})
 		}
 	}
 
-	// Check next char
-	end := index + len(word)
-	if end < len(s) {
-		next := s[end]
-		if (next >= 'z' && next <= 'z') || (next >= '0' && next <= '9') {
-			return false
+	// Hinted assets missing from the lexicon still get tagged
+	if item.AssetHint != "" && !tagsContain(tags, item.AssetHint) {
+		tags = append(tags, AssetTag{Symbol: strings.ToUpper(item.AssetHint), Relevance: 1.0})
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		// The hint stays primary even when a headline mention ties it
+		if hint := strings.ToUpper(item.AssetHint); hint != "" && (tags[i].Symbol == hint) != (tags[j].Symbol == hint) {
+			return tags[i].Symbol == hint
+		}
+		return tags[i].Relevance > tags[j].Relevance
+	})
+	return tags
+}
+
+func tagsContain(tags []AssetTag, symbol string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t.Symbol, symbol) {
+			return true
 		}
 	}
+	return false
+}
 
-	return true
+// isSecurityRelease checks a release's title and notes for security fixes
+func isSecurityRelease(lex *Lexicon, item *NewsItem) bool {
+	return Tokenize(item.Title + "\n" + truncate(item.Body, 3000)).HasAny(lex.SecurityTerms)
 }
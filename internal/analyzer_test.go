@@ -0,0 +1,60 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeNewsModifiers(t *testing.T) {
+	tests := []struct {
+		title     string
+		sentiment int // -1 negative, 0 neutral, 1 positive
+		category  string
+		note      string // Substring expected in SentimentNote, "" = none
+		hedged    bool   // Impact lowered by the impact factor
+	}{
+		{"Bitcoin gains 5% overnight", 1, "", "", false},
+		{"Bitcoin did not crash", 1, "", `negated "crash" (not)`, false},
+		{"Solana fails to hold gains", -1, "", `negated "gains" (fails to)`, false},
+		{"No, Bitcoin is not dead: it rallies", 1, "", "", false},
+		{"Bitcoin rallies, a rate cut may follow", 1, "", "", false},
+		{"Bitcoin could rally 20%, analysts say", 1, "", `hedged "rally" (could)`, true},
+		{"Solana rally rumored", 1, "", `hedged "rally" (rumor)`, true},
+		{"Will Ethereum crash again?", -1, "", "question headline", true},
+		{"Binance denies listing PEPE", -1, "", "no listing (denies)", false},
+		{"Binance will list PEPE", 1, "LISTING", "", false},
+		{"Binance halts withdrawals after hack", -1, "HACK", "", false},
+		{"Exchange delays withdrawals amid exploit", -1, "HACK", "", false},
+	}
+
+	lex := CurrentLexicon()
+	for _, tt := range tests {
+		item := NewsItem{Title: tt.title}
+		AnalyzeNews(&item)
+
+		got := 0
+		if item.Sentiment > 0 {
+			got = 1
+		} else if item.Sentiment < 0 {
+			got = -1
+		}
+		if got != tt.sentiment {
+			t.Errorf("%q: sentiment %.2f, want sign %d", tt.title, item.Sentiment, tt.sentiment)
+		}
+		if item.Category != tt.category {
+			t.Errorf("%q: category %q, want %q", tt.title, item.Category, tt.category)
+		}
+		if tt.note == "" && item.SentimentNote != "" {
+			t.Errorf("%q: unexpected note %q", tt.title, item.SentimentNote)
+		}
+		if tt.note != "" && !strings.Contains(item.SentimentNote, tt.note) {
+			t.Errorf("%q: note %q, want it to contain %q", tt.title, item.SentimentNote, tt.note)
+		}
+		if tt.hedged && item.Impact >= lex.DefaultImpact {
+			t.Errorf("%q: impact %.2f not lowered below %.2f", tt.title, item.Impact, lex.DefaultImpact)
+		}
+		if tt.category == "HACK" && item.Impact < 1.0 {
+			t.Errorf("%q: impact %.2f, want the hack event's 1.0", tt.title, item.Impact)
+		}
+	}
+}
@@ -0,0 +1,398 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RegistryAsset is one tradable asset the analyzer can recognize
+type RegistryAsset struct {
+	Symbol    string   `json:"symbol"`
+	Name      string   `json:"name,omitempty"`
+	Aliases   []string `json:"aliases,omitempty"`
+	Ambiguous *bool    `json:"ambiguous,omitempty"` // nil = decide from the ticker (see tickerUseFor); true = treat like a dictionary word
+}
+
+// AssetRegistryFile is the on-disk asset dictionary (assets.json)
+type AssetRegistryFile struct {
+	Updated time.Time       `json:"updated"`
+	Assets  []RegistryAsset `json:"assets"`
+}
+
+// tickerUse says where an uppercase ticker counts as a mention of the asset
+type tickerUse int
+
+const (
+	tickerBare        tickerUse = iota // Anywhere: "PEPE"
+	tickerNearContext                  // Next to a word like "token" or "price": "OP price"
+	tickerStrict                       // Only "$ONE", "(ONE)", "ONE/USDT" or "ONE token"
+)
+
+// assetMatcher is an asset compiled for matching against headlines
+type assetMatcher struct {
+	symbol string
+	terms  []string // Curated aliases, matched case-insensitively as whole words (see WordIndex)
+	names  []string // Registry names, matched as written: "Optimism", not "optimism"
+	ticker tickerUse
+}
+
+// assetSpan is one mention of an asset: text[start:end]
+type assetSpan struct {
+	start, end int
+	explicit   bool // A spelled-out ticker: "$PEPE", "(PEPE)"
+}
+
+var (
+	assetRegistryMu sync.RWMutex
+	registryAssets  []RegistryAsset
+)
+
+// AssetRegistryPath returns ASSETS_PATH or the default ./assets.json
+func AssetRegistryPath() string {
+	if path := os.Getenv("ASSETS_PATH"); path != "" {
+		return path
+	}
+	return "./assets.json"
+}
+
+// LoadAssetRegistry reads and validates an asset registry file
+func LoadAssetRegistry(path string) (*AssetRegistryFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file AssetRegistryFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%s: invalid JSON: %v", path, err)
+	}
+
+	seen := make(map[string]bool)
+	for i := range file.Assets {
+		a := &file.Assets[i]
+		a.Symbol = strings.ToUpper(strings.TrimSpace(a.Symbol))
+		a.Name = strings.TrimSpace(a.Name)
+		if a.Symbol == "" || strings.ContainsAny(a.Symbol, " \t") {
+			return nil, fmt.Errorf("%s: asset %d has a bad symbol %q", path, i, a.Symbol)
+		}
+		if seen[a.Symbol] {
+			return nil, fmt.Errorf("%s: duplicate symbol %s", path, a.Symbol)
+		}
+		seen[a.Symbol] = true
+	}
+	return &file, nil
+}
+
+// ReloadAssetRegistry swaps in the registry file; a missing file means an
+// empty registry (the lexicon's assets still work). On error the current
+// registry stays.
+func ReloadAssetRegistry(path string) error {
+	file, err := LoadAssetRegistry(path)
+	if os.IsNotExist(err) {
+		file, err = &AssetRegistryFile{}, nil
+	}
+	if err != nil {
+		return err
+	}
+
+	assetRegistryMu.Lock()
+	registryAssets = file.Assets
+	assetRegistryMu.Unlock()
+	if len(file.Assets) > 0 {
+		log.Printf("🪙 Asset registry loaded: %d assets", len(file.Assets))
+	}
+	return nil
+}
+
+// assetMatchers merges the lexicon's curated assets (first, in order) with
+// the registry. Registry names and aliases extend curated entries.
+func assetMatchers(lex *Lexicon) []assetMatcher {
+	assetRegistryMu.RLock()
+	registry := registryAssets
+	assetRegistryMu.RUnlock()
+
+	var matchers []assetMatcher
+	bySymbol := make(map[string]int)
+	for _, a := range lex.Assets {
+		bySymbol[a.Symbol] = len(matchers)
+		matchers = append(matchers, assetMatcher{symbol: a.Symbol, terms: a.Aliases, ticker: tickerNearContext})
+	}
+
+	for _, a := range registry {
+		m := assetMatcher{symbol: a.Symbol, ticker: tickerUseFor(lex, a)}
+		if a.Name != "" && a.Name != a.Symbol {
+			m.names = append(m.names, a.Name)
+		}
+		for _, alias := range a.Aliases {
+			if alias = strings.ToLower(strings.TrimSpace(alias)); alias != "" {
+				m.terms = append(m.terms, alias)
+			}
+		}
+
+		if i, ok := bySymbol[a.Symbol]; ok {
+			matchers[i].terms = append(matchers[i].terms, m.terms...)
+			matchers[i].names = append(matchers[i].names, m.names...)
+			matchers[i].ticker = m.ticker
+			continue
+		}
+		bySymbol[a.Symbol] = len(matchers)
+		matchers = append(matchers, m)
+	}
+	return matchers
+}
+
+// spans returns every mention of the asset in text
+func (m assetMatcher) spans(text *TokenizedText) []assetSpan {
+	var spans []assetSpan
+	for _, term := range m.terms {
+		for _, s := range text.WordSpans(term) {
+			spans = append(spans, assetSpan{start: s[0], end: s[1]})
+		}
+	}
+	for _, name := range m.names {
+		for _, s := range text.CaseSpans(name) {
+			spans = append(spans, assetSpan{start: s[0], end: s[1]})
+		}
+	}
+	// In an all-caps headline every word looks like a ticker
+	use := m.ticker
+	if use == tickerBare && isShouting(text.Raw) {
+		use = tickerNearContext
+	}
+	return append(spans, matchTicker(text.Raw, m.symbol, use)...)
+}
+
+// assetHit is where an asset is first mentioned in a text (-1 if not at all)
+// and whether any mention spells its ticker out
+type assetHit struct {
+	at       int
+	explicit bool
+}
+
+// assetMentions finds each matcher's mentions in text. A mention inside a
+// longer one of another asset doesn't count: "Bitcoin Cash" is BCH, not
+// BTC, and "Ethereum Classic" is ETC, not ETH.
+func assetMentions(matchers []assetMatcher, text *TokenizedText) []assetHit {
+	spans := make([][]assetSpan, len(matchers))
+	for k, m := range matchers {
+		spans[k] = m.spans(text)
+	}
+
+	hits := make([]assetHit, len(matchers))
+	for k := range matchers {
+		hits[k].at = -1
+		for _, s := range spans[k] {
+			if insideLongerSpan(s, k, spans) {
+				continue
+			}
+			if hits[k].at < 0 || s.start < hits[k].at {
+				hits[k].at = s.start
+			}
+			hits[k].explicit = hits[k].explicit || s.explicit
+		}
+	}
+	return hits
+}
+
+// insideLongerSpan reports whether s (a mention of asset k) lies within a
+// longer mention of any other asset
+func insideLongerSpan(s assetSpan, k int, spans [][]assetSpan) bool {
+	for other, list := range spans {
+		if other == k {
+			continue
+		}
+		for _, o := range list {
+			if o.start <= s.start && s.end <= o.end && o.end-o.start > s.end-s.start {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// tickerContext are words that, next to an all-caps ticker, show it means the token
+var tickerContext = map[string]bool{
+	"token": true, "tokens": true, "coin": true, "price": true, "network": true, "mainnet": true,
+	"airdrop": true, "listing": true, "perpetual": true, "futures": true, "usdt": true, "staking": true,
+}
+
+// strictTickerContext are the only context words that make a dictionary-word
+// ticker count: "ONE token", but not "price NEAR"
+var strictTickerContext = map[string]bool{"token": true, "tokens": true, "coin": true}
+
+// matchTicker finds an uppercase ticker where it clearly means the asset:
+// "$OP", "(OP)", "OP/USDT", "OP-USD" (explicit) always count; "OP" next to a
+// context word counts unless use is tickerStrict, which only accepts
+// "token" or "coin"; tickerBare also takes a plain uppercase "PEPE".
+func matchTicker(text, ticker string, use tickerUse) []assetSpan {
+	var spans []assetSpan
+	for from := 0; from < len(text); {
+		i := strings.Index(text[from:], ticker)
+		if i < 0 {
+			break
+		}
+		i += from
+		end := i + len(ticker)
+		from = end
+
+		prev, next := byte(' '), byte(' ')
+		if i > 0 {
+			prev = text[i-1]
+		}
+		if end < len(text) {
+			next = text[end]
+		}
+		if (isWordByte(prev) && prev != '$') || isWordByte(next) {
+			continue // Part of a longer word
+		}
+
+		span := assetSpan{start: i, end: end}
+		switch {
+		case prev == '$':
+			span.start, span.explicit = i-1, true
+		case prev == '(' && next == ')', next == '/', strings.HasPrefix(text[end:], "-USD"):
+			span.explicit = true
+		case use == tickerBare:
+		case use == tickerStrict && !nextToTickerContext(text[:i], text[end:], strictTickerContext):
+			continue
+		case !nextToTickerContext(text[:i], text[end:], tickerContext):
+			continue
+		}
+		spans = append(spans, span)
+	}
+	return spans
+}
+
+// nextToTickerContext reports whether the word just before or after a
+// ticker is one of the context words
+func nextToTickerContext(before, after string, context map[string]bool) bool {
+	b, a := strings.Fields(before), strings.Fields(after)
+	if len(a) > 0 && context[strings.ToLower(strings.Trim(a[0], ".,:;!?"))] {
+		return true
+	}
+	return len(b) > 0 && context[strings.ToLower(strings.Trim(b[len(b)-1], ".,:;!?"))]
+}
+
+func isWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' || b == '$'
+}
+
+// isShouting reports whether text has letters but no lowercase ones
+func isShouting(text string) bool {
+	return strings.ToUpper(text) == text && strings.ToLower(text) != text
+}
+
+// dictionaryTickers are tickers that are also English words headlines write
+// in capitals for emphasis ("Bitcoin price NEAR all-time high")
+var dictionaryTickers = map[string]bool{
+	"ONE": true, "NEAR": true, "GAS": true, "SUN": true, "LINK": true, "SAND": true,
+	"HOT": true, "HIGH": true, "MASK": true, "FLOW": true, "ROSE": true, "MOVE": true,
+	"TRUMP": true, "GOLD": true, "BEAM": true, "ACE": true, "NOT": true, "THE": true,
+}
+
+// tickerUseFor decides where a registry ticker counts. Dictionary words (and
+// entries marked "ambiguous") need "$", parentheses or "token"/"coin";
+// one/two-letter tickers ("OP", "AI") and market keywords ("ETF") need some
+// ticker context; anything else may stand alone in uppercase.
+func tickerUseFor(lex *Lexicon, a RegistryAsset) tickerUse {
+	if a.Ambiguous != nil {
+		if *a.Ambiguous {
+			return tickerStrict
+		}
+		return tickerBare
+	}
+	if dictionaryTickers[a.Symbol] {
+		return tickerStrict
+	}
+	if len(a.Symbol) <= 2 {
+		return tickerNearContext
+	}
+	for _, kw := range lex.MarketKeywords {
+		if kw == strings.ToLower(a.Symbol) {
+			return tickerNearContext
+		}
+	}
+	return tickerBare
+}
+
+// exchangeInfo is the part of Binance's /api/v3/exchangeInfo dump we read
+type exchangeInfo struct {
+	Symbols []struct {
+		BaseAsset string `json:"baseAsset"`
+		Status    string `json:"status"`
+	} `json:"symbols"`
+}
+
+// SyncAssetRegistry merges the base assets of an exchange-info dump (or a
+// plain [{"symbol", "name"}] list) into the registry file at path, keeping
+// names, aliases and ambiguity overrides already there. Returns how many
+// assets were added.
+func SyncAssetRegistry(path string, dump io.Reader) (int, error) {
+	data, err := io.ReadAll(dump)
+	if err != nil {
+		return 0, err
+	}
+
+	var incoming []RegistryAsset
+	var info exchangeInfo
+	if err := json.Unmarshal(data, &info); err == nil && len(info.Symbols) > 0 {
+		for _, s := range info.Symbols {
+			if s.BaseAsset != "" && (s.Status == "" || s.Status == "TRADING") {
+				incoming = append(incoming, RegistryAsset{Symbol: s.BaseAsset})
+			}
+		}
+	} else if err := json.Unmarshal(data, &incoming); err != nil {
+		return 0, fmt.Errorf("unrecognized dump: expected exchangeInfo or a [{symbol, name}] list")
+	}
+
+	// Shared with the sources config writers (see configWriteMu)
+	configWriteMu.Lock()
+	defer configWriteMu.Unlock()
+
+	file, err := LoadAssetRegistry(path)
+	if os.IsNotExist(err) {
+		file, err = &AssetRegistryFile{}, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	index := make(map[string]int)
+	for i, a := range file.Assets {
+		index[a.Symbol] = i
+	}
+	added := 0
+	for _, a := range incoming {
+		a.Symbol = strings.ToUpper(strings.TrimSpace(a.Symbol))
+		if a.Symbol == "" {
+			continue
+		}
+		if i, ok := index[a.Symbol]; ok {
+			if file.Assets[i].Name == "" {
+				file.Assets[i].Name = strings.TrimSpace(a.Name)
+			}
+			continue
+		}
+		index[a.Symbol] = len(file.Assets)
+		file.Assets = append(file.Assets, RegistryAsset{Symbol: a.Symbol, Name: strings.TrimSpace(a.Name)})
+		added++
+	}
+
+	sort.Slice(file.Assets, func(i, j int) bool { return file.Assets[i].Symbol < file.Assets[j].Symbol })
+	file.Updated = time.Now().UTC()
+	data, err = json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return 0, err
+	}
+	return added, os.Rename(tmp, path)
+}
@@ -0,0 +1,74 @@
+package internal
+
+import "testing"
+
+func TestTagAssetsRegistry(t *testing.T) {
+	assetRegistryMu.Lock()
+	saved := registryAssets
+	registryAssets = []RegistryAsset{
+		{Symbol: "PEPE", Name: "Pepe"},
+		{Symbol: "OP", Name: "Optimism"},
+		{Symbol: "ETC", Name: "Ethereum Classic"},
+		{Symbol: "DOT", Name: "Polkadot"},
+		{Symbol: "UNI", Name: "Uniswap"},
+		{Symbol: "NOT", Name: "Notcoin"},
+		{Symbol: "THE", Name: "THENA"},
+		{Symbol: "ONE", Name: "Harmony ONE"},
+		{Symbol: "BCH", Name: "Bitcoin Cash"},
+		{Symbol: "NEAR", Name: "NEAR Protocol"},
+		{Symbol: "LINK", Name: "Chainlink"},
+		{Symbol: "GAS", Name: "Neo Gas"},
+	}
+	assetRegistryMu.Unlock()
+	defer func() {
+		assetRegistryMu.Lock()
+		registryAssets = saved
+		assetRegistryMu.Unlock()
+	}()
+
+	tests := []struct {
+		title   string
+		primary string // "" = no asset tagged
+		absent  string // Must not be tagged at all
+	}{
+		{"Binance Will List Pepe (PEPE)", "PEPE", ""},
+		{"Binance Will List Optimism (OP)", "OP", ""},
+		{"SEC does not approve the Bitcoin ETF", "BTC", ""},
+		{"Fees, taxes, etc. explained", "", ""},
+		{"Dot-com bubble lessons for crypto", "", ""},
+		{"Uni students learn about crypto", "", ""},
+		{"One more thing", "", ""},
+		{"OP token unlock next week", "OP", ""},
+		{"UNI jumps after fee switch vote", "UNI", ""},
+		{"BITCOIN HITS NEW HIGH AS THE MARKET RALLIES", "BTC", ""},
+
+		// A longer name wins over the alias inside it
+		{"Bitcoin Cash surges 20% after upgrade", "BCH", "BTC"},
+		{"Bitcoin Cash (BCH) surges", "BCH", "BTC"},
+		{"Ethereum Classic hit by 51% attack", "ETC", "ETH"},
+		{"Bitcoin Cash lags as Bitcoin rallies", "BCH", ""},
+
+		// Dictionary-word tickers need "$", parentheses or "token"/"coin"
+		{"Bitcoin price NEAR all-time high", "BTC", "NEAR"},
+		{"ONE more rate cut, says Fed", "", "ONE"},
+		{"GAS fees spike on Ethereum", "ETH", "GAS"},
+		{"Chainlink LINK integration goes live", "LINK", ""},
+		{"$NEAR rallies after upgrade", "NEAR", ""},
+		{"Harmony (ONE) bridge reopens", "ONE", ""},
+		{"LINK token jumps 10%", "LINK", ""},
+	}
+	for _, tt := range tests {
+		item := NewsItem{Title: tt.title}
+		AnalyzeNews(&item)
+		got := ""
+		if len(item.Assets) > 0 {
+			got = item.Assets[0].Symbol
+		}
+		if got != tt.primary {
+			t.Errorf("%q: primary asset %q (tags %v), want %q", tt.title, got, item.Assets, tt.primary)
+		}
+		if tt.absent != "" && tagsContain(item.Assets, tt.absent) {
+			t.Errorf("%q: tagged %s (tags %v)", tt.title, tt.absent, item.Assets)
+		}
+	}
+}
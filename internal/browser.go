@@ -0,0 +1,173 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+const browserHealthInterval = 30 * time.Second
+
+// BrowserPool keeps one long-lived headless Chrome and hands out tabs to
+// scrapers, so each poll doesn't pay for launching a new browser.
+type BrowserPool struct {
+	tabs chan struct{} // Semaphore capping concurrent tabs
+
+	mu            sync.Mutex
+	allocCancel   context.CancelFunc
+	browserCtx    context.Context
+	browserCancel context.CancelFunc
+	healthOnce    sync.Once
+}
+
+// Browsers is the shared pool used by every chromedp-based collector
+var Browsers = NewBrowserPool(browserMaxTabs())
+
+func browserMaxTabs() int {
+	if n, err := strconv.Atoi(os.Getenv("BROWSER_MAX_TABS")); err == nil && n > 0 {
+		return n
+	}
+	return 2
+}
+
+// NewBrowserPool creates a pool; Chrome is started lazily on the first lease
+func NewBrowserPool(maxTabs int) *BrowserPool {
+	return &BrowserPool{tabs: make(chan struct{}, maxTabs)}
+}
+
+// Lease opens a new tab in the shared browser. The tab closes when release
+// is called or ctx ends, whichever comes first.
+func (p *BrowserPool) Lease(ctx context.Context) (tabCtx context.Context, release func(), err error) {
+	select {
+	case p.tabs <- struct{}{}:
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+
+	browserCtx, err := p.browser()
+	if err != nil {
+		<-p.tabs
+		return nil, nil, err
+	}
+
+	tabCtx, tabCancel := chromedp.NewContext(browserCtx)
+	stop := context.AfterFunc(ctx, tabCancel)
+
+	var once sync.Once
+	release = func() {
+		once.Do(func() {
+			stop()
+			tabCancel()
+			<-p.tabs
+		})
+	}
+	return tabCtx, release, nil
+}
+
+// browser returns the running browser context, starting Chrome if needed
+func (p *BrowserPool) browser() (context.Context, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.browserCtx != nil && p.browserCtx.Err() == nil {
+		return p.browserCtx, nil
+	}
+	if err := p.startLocked(); err != nil {
+		return nil, err
+	}
+
+	p.healthOnce.Do(func() { go p.healthLoop() })
+	return p.browserCtx, nil
+}
+
+func (p *BrowserPool) startLocked() error {
+	p.stopLocked()
+
+	opts := append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.Flag("headless", true),
+		chromedp.Flag("disable-gpu", true),
+		chromedp.Flag("enable-automation", false),                       // Key for stealth
+		chromedp.Flag("disable-blink-features", "AutomationControlled"), // Key for stealth
+		chromedp.UserAgent("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"),
+	)
+
+	allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(), opts...)
+	browserCtx, browserCancel := chromedp.NewContext(allocCtx)
+
+	// Run with no actions just launches the browser
+	if err := chromedp.Run(browserCtx); err != nil {
+		browserCancel()
+		allocCancel()
+		return fmt.Errorf("browser start failed: %v", err)
+	}
+
+	p.allocCancel, p.browserCtx, p.browserCancel = allocCancel, browserCtx, browserCancel
+	log.Println("🌐 Headless browser started")
+	return nil
+}
+
+func (p *BrowserPool) stopLocked() {
+	if p.browserCancel != nil {
+		p.browserCancel()
+	}
+	if p.allocCancel != nil {
+		p.allocCancel()
+	}
+	p.allocCancel, p.browserCtx, p.browserCancel = nil, nil, nil
+}
+
+// healthLoop opens a blank tab periodically and restarts Chrome if it is unresponsive
+func (p *BrowserPool) healthLoop() {
+	for range time.Tick(browserHealthInterval) {
+		p.mu.Lock()
+		browserCtx := p.browserCtx
+		p.mu.Unlock()
+
+		if browserCtx == nil {
+			continue // Closed, or never needed since the last restart
+		}
+		if browserCtx.Err() == nil && p.ping(browserCtx) == nil {
+			continue
+		}
+
+		log.Println("🩺 Headless browser unresponsive, restarting...")
+		p.mu.Lock()
+		if p.browserCtx == browserCtx {
+			if err := p.startLocked(); err != nil {
+				log.Printf("⚠️  %v", err)
+			}
+		}
+		p.mu.Unlock()
+	}
+}
+
+// ping opens a blank tab, taking a tab slot like Lease so the health check
+// never goes over the tab limit. If every slot stays busy it skips the check:
+// scrapers are using the browser, and their own timeouts catch a hang.
+func (p *BrowserPool) ping(browserCtx context.Context) error {
+	select {
+	case p.tabs <- struct{}{}:
+		defer func() { <-p.tabs }()
+	case <-time.After(10 * time.Second):
+		return nil
+	}
+
+	tabCtx, cancel := chromedp.NewContext(browserCtx)
+	defer cancel()
+	tabCtx, cancel = context.WithTimeout(tabCtx, 10*time.Second)
+	defer cancel()
+	return chromedp.Run(tabCtx, chromedp.Navigate("about:blank"))
+}
+
+// Close shuts the browser down; the next lease starts a new one
+func (p *BrowserPool) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stopLocked()
+}
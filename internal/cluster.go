@@ -0,0 +1,243 @@
+package internal
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// StoryMember is one source's coverage of a story
+type StoryMember struct {
+	ID        string    `json:"ID"`
+	Source    string    `json:"Source"`
+	Title     string    `json:"Title"`
+	Link      string    `json:"Link,omitempty"`
+	Timestamp time.Time `json:"Timestamp"`
+}
+
+// Story groups near-duplicate items about the same event.
+// The first item seen is the canonical one; its ID doubles as the StoryID.
+type Story struct {
+	StoryID     string        `json:"StoryID"`
+	CanonicalID string        `json:"CanonicalID"`
+	Title       string        `json:"Title"`
+	Sources     []string      `json:"Sources"`
+	Members     []StoryMember `json:"Members"`
+	FirstSeen   time.Time     `json:"FirstSeen"`
+	LastSeen    time.Time     `json:"LastSeen"`
+}
+
+type storyState struct {
+	story     Story
+	shingles  []map[string]bool // One set per member
+	asset     string            // Canonical's primary asset, "" when none
+	sentiment float64           // Canonical's rule-based sentiment
+}
+
+// StoryClusterer assigns incoming items to stories by title similarity
+type StoryClusterer struct {
+	window    time.Duration // Only match stories active within this window
+	threshold float64       // Minimum Jaccard similarity to join a story
+
+	mu      sync.Mutex
+	stories []*storyState
+	byID    map[string]*storyState
+}
+
+// NewStoryClusterer creates a clusterer; 24h / 0.65 are sensible defaults
+func NewStoryClusterer(window time.Duration, threshold float64) *StoryClusterer {
+	return &StoryClusterer{
+		window:    window,
+		threshold: threshold,
+		byID:      make(map[string]*storyState),
+	}
+}
+
+// Assign puts item into an existing story (duplicate == true) or starts a new
+// one, and sets item.StoryID / item.Coverage. It returns the updated story.
+func (c *StoryClusterer) Assign(item *NewsItem) (story Story, duplicate bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pruneLocked()
+	shingles := titleShingles(item.EnglishTitle()) // Match translated headlines across languages
+
+	var best *storyState
+	bestScore := 0.0
+	for _, st := range c.stories {
+		if absDuration(item.Timestamp.Sub(st.story.LastSeen)) > c.window || !st.accepts(item) {
+			continue
+		}
+		for _, other := range st.shingles {
+			if score := jaccard(shingles, other); score > bestScore {
+				best, bestScore = st, score
+			}
+		}
+	}
+
+	if best == nil || bestScore < c.threshold {
+		st := &storyState{story: Story{
+			StoryID:     item.ID,
+			CanonicalID: item.ID,
+			Title:       item.Title,
+			FirstSeen:   item.Timestamp,
+			LastSeen:    item.Timestamp,
+		}, asset: storyAsset(item), sentiment: item.Sentiment}
+		c.stories = append(c.stories, st)
+		c.byID[item.ID] = st
+		c.addMemberLocked(st, item, shingles)
+		return copyStory(st.story), false
+	}
+
+	c.addMemberLocked(best, item, shingles)
+	return copyStory(best.story), true
+}
+
+// Restore re-adds a persisted item to the story it was assigned before a
+// restart. Items must be restored oldest first so canonicals come first.
+func (c *StoryClusterer) Restore(item NewsItem) {
+	c.mu.Lock()
+	st, ok := c.byID[item.StoryID]
+	if ok && item.StoryID != item.ID {
+		c.addMemberLocked(st, &item, titleShingles(item.EnglishTitle()))
+		c.mu.Unlock()
+		return
+	}
+	c.mu.Unlock()
+
+	// Canonical item, or its canonical fell out of history: cluster afresh
+	c.Assign(&item)
+}
+
+// accepts reports whether item may join the story at all, however similar
+// the titles: a source never reports the same story twice (two listings from
+// one exchange are two events), the primary asset must agree, and opposite
+// sentiment means opposite news ("inflows" vs "outflows").
+func (st *storyState) accepts(item *NewsItem) bool {
+	if containsString(st.story.Sources, item.Source) {
+		return false
+	}
+	if st.asset != storyAsset(item) {
+		return false
+	}
+	return st.sentiment*item.Sentiment >= 0
+}
+
+// storyAsset is the item's primary asset, with the "no specific asset"
+// markers (ALT / ALL) folded into ""
+func storyAsset(item *NewsItem) string {
+	if item.Asset == "ALT" || item.Asset == "ALL" {
+		return ""
+	}
+	return item.Asset
+}
+
+func (c *StoryClusterer) addMemberLocked(st *storyState, item *NewsItem, shingles map[string]bool) {
+	st.story.Members = append(st.story.Members, StoryMember{
+		ID: item.ID, Source: item.Source, Title: item.Title, Link: item.Link, Timestamp: item.Timestamp,
+	})
+	st.shingles = append(st.shingles, shingles)
+	if !containsString(st.story.Sources, item.Source) {
+		st.story.Sources = append(st.story.Sources, item.Source)
+	}
+	if item.Timestamp.After(st.story.LastSeen) {
+		st.story.LastSeen = item.Timestamp
+	}
+	item.StoryID, item.Coverage = st.story.StoryID, len(st.story.Sources)
+}
+
+// Get returns a single story by ID
+func (c *StoryClusterer) Get(storyID string) (Story, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	st, ok := c.byID[storyID]
+	if !ok {
+		return Story{}, false
+	}
+	return copyStory(st.story), true
+}
+
+// Stories lists active stories covered by at least minSources sources, newest first
+func (c *StoryClusterer) Stories(minSources int) []Story {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var list []Story
+	for _, st := range c.stories {
+		if len(st.story.Sources) >= minSources {
+			list = append(list, copyStory(st.story))
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].LastSeen.After(list[j].LastSeen) })
+	return list
+}
+
+// pruneLocked forgets stories that went quiet long ago
+func (c *StoryClusterer) pruneLocked() {
+	cutoff := time.Now().Add(-2 * c.window)
+	kept := c.stories[:0]
+	for _, st := range c.stories {
+		if st.story.LastSeen.Before(cutoff) {
+			delete(c.byID, st.story.StoryID)
+			continue
+		}
+		kept = append(kept, st)
+	}
+	c.stories = kept
+}
+
+// titleStopwords carry no signal about which event a headline is about
+var titleStopwords = map[string]bool{
+	"a": true, "an": true, "the": true, "and": true, "or": true, "of": true, "to": true, "in": true,
+	"on": true, "for": true, "as": true, "at": true, "by": true, "with": true, "from": true, "is": true,
+	"are": true, "be": true, "its": true, "it": true, "this": true, "that": true, "after": true,
+	"will": true, "new": true, "says": true, "over": true, "into": true, "amid": true,
+}
+
+// titleShingles normalizes a headline into its set of meaningful word stems,
+// so "ETF" and "ETFs" count as the same word
+func titleShingles(title string) map[string]bool {
+	set := make(map[string]bool)
+	for _, tok := range Tokenize(title).Tokens {
+		if titleStopwords[tok.Word] {
+			continue
+		}
+		set[tok.Stem] = true
+	}
+	return set
+}
+
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for w := range a {
+		if b[w] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+func copyStory(s Story) Story {
+	s.Sources = append([]string(nil), s.Sources...)
+	s.Members = append([]StoryMember(nil), s.Members...)
+	return s
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
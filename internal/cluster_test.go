@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStoryClustererAssign(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		first     NewsItem
+		second    NewsItem
+		duplicate bool
+	}{
+		{
+			name:      "same story, two outlets",
+			first:     NewsItem{ID: "a", Source: "CoinDesk", Title: "SEC approves spot Bitcoin ETFs", Asset: "BTC"},
+			second:    NewsItem{ID: "b", Source: "The Block", Title: "SEC approves spot Bitcoin ETF", Asset: "BTC"},
+			duplicate: true,
+		},
+		{
+			name:   "two listings from one exchange",
+			first:  NewsItem{ID: "a", Source: "Binance", Title: "Binance Will List Pepe (PEPE)", Asset: "PEPE"},
+			second: NewsItem{ID: "b", Source: "Binance", Title: "Binance Will List Bonk (BONK)", Asset: "BONK"},
+		},
+		{
+			name:   "same source never merges",
+			first:  NewsItem{ID: "a", Source: "Binance", Title: "Binance Will List Pepe (PEPE)", Asset: "PEPE"},
+			second: NewsItem{ID: "b", Source: "Binance", Title: "Binance Will List Pepe (PEPE) Perpetual", Asset: "PEPE"},
+		},
+		{
+			name:   "different primary asset",
+			first:  NewsItem{ID: "a", Source: "CoinDesk", Title: "Binance will list PEPE", Asset: "PEPE"},
+			second: NewsItem{ID: "b", Source: "The Block", Title: "Binance will list BONK", Asset: "BONK"},
+		},
+		{
+			name:   "opposite flows",
+			first:  NewsItem{ID: "a", Source: "CoinDesk", Title: "Ethereum ETF inflows rise", Asset: "ETH"},
+			second: NewsItem{ID: "b", Source: "The Block", Title: "Ethereum ETF outflows rise", Asset: "ETH", Sentiment: -0.3},
+		},
+	}
+
+	for _, tt := range tests {
+		c := NewStoryClusterer(24*time.Hour, 0.65)
+		tt.first.Timestamp, tt.second.Timestamp = now, now
+		c.Assign(&tt.first)
+		if _, dup := c.Assign(&tt.second); dup != tt.duplicate {
+			t.Errorf("%s: duplicate = %v, want %v", tt.name, dup, tt.duplicate)
+		}
+	}
+}
@@ -1,20 +1,179 @@
 package internal
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
-	"github.com/chromedp/chromedp"
 	"github.com/mmcdole/gofeed"
+	"io"
 	"net/http"
 	"strings"
 	"time"
 )
 
-// FetchRSS fetches news from a standard RSS feed
-func FetchRSS(url string, sourceName string) ([]NewsItem, error) {
-	fp := gofeed.NewParser()
-	feed, err := fp.ParseURL(url)
+func init() {
+	RegisterSourceKind(KindRSS, newRSSSource)
+	RegisterSourceKind(KindHeadless, newHeadlessSource)
+	RegisterSourceKind(KindBinanceAPI, newBinanceAPISource)
+	RegisterSourceKind(KindHTML, newHTMLSource)
+	RegisterSourceKind(KindJSON, newJSONSource)
+	RegisterSourceKind(KindJSONAPI, newJSONSource)
+	RegisterSourceKind(KindReddit, newRedditSource)
+	RegisterSourceKind(KindDiscourse, newDiscourseSource)
+	RegisterSourceKind(KindGitHub, newGitHubSource)
+	RegisterSourceKind(KindTelegram, newTelegramSource)
+}
+
+// rssSource polls a standard RSS/Atom feed
+type rssSource struct {
+	name        string
+	url         string
+	extractBody bool
+}
+
+func newRSSSource(cfg SourceConfig) (Source, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("rss source %s has no url", cfg.Name)
+	}
+	return &rssSource{name: cfg.Name, url: cfg.URL, extractBody: cfg.ExtractBody}, nil
+}
+
+func (s *rssSource) Name() string { return s.name }
+func (s *rssSource) Kind() string { return KindRSS }
+
+func (s *rssSource) Fetch(ctx context.Context) ([]NewsItem, error) {
+	return FetchRSS(ctx, s.url, s.name)
+}
+
+// Enrich replaces the feed's body with the extracted article text when enabled
+func (s *rssSource) Enrich(ctx context.Context, item *NewsItem) error {
+	if !s.extractBody || item.Link == "" {
+		return nil
+	}
+	body, err := ExtractArticle(ctx, item.Link)
+	if err != nil {
+		return err
+	}
+	if len(body) > len(item.Body) {
+		item.Body = body
+	}
+	return nil
+}
+
+// headlessSource scrapes the Binance announcement page with a real browser
+type headlessSource struct {
+	name string
+}
+
+// newHeadlessSource builds a config-driven headless scraper, or the built-in
+// Binance scraper when no url is given
+func newHeadlessSource(cfg SourceConfig) (Source, error) {
+	if cfg.URL == "" {
+		return &headlessSource{name: cfg.Name}, nil
+	}
+	if cfg.Scrape == nil || (cfg.Scrape.Items == "" && cfg.Scrape.Script == "") {
+		return nil, fmt.Errorf("headless source %s needs scrape.items or scrape.script", cfg.Name)
+	}
+	return &scrapeSource{name: cfg.Name, kind: KindHeadless, url: cfg.URL, spec: *cfg.Scrape, headless: true}, nil
+}
+
+func (s *headlessSource) Name() string { return s.name }
+func (s *headlessSource) Kind() string { return KindHeadless }
+
+func (s *headlessSource) Fetch(ctx context.Context) ([]NewsItem, error) {
+	return FetchBinanceHeadless(ctx)
+}
+
+// feedClient is shared so keep-alive connections are reused between polls
+var feedClient = &http.Client{Timeout: 30 * time.Second}
+
+// fetchFeed downloads a feed with a conditional GET, also returning when it
+// was fetched (the recording time in replay mode).
+// Returns a nil feed (and no error) when the publisher answers 304 Not Modified.
+func fetchFeed(ctx context.Context, url string) (*gofeed.Feed, time.Time, error) {
+	var fetched bool
+	var etag, lastModified string
+	data, fetchedAt, err := fetchPayload(url, "xml", func() ([]byte, error) {
+		var body []byte
+		var err error
+		body, etag, lastModified, err = downloadFeed(ctx, url)
+		fetched = err == nil && body != nil
+		return body, err
+	})
+	if err != nil || data == nil {
+		return nil, fetchedAt, err
+	}
+
+	feed, err := gofeed.NewParser().Parse(bytes.NewReader(data))
+	if err != nil {
+		return nil, fetchedAt, err
+	}
+
+	// Only remember validators once the body parsed cleanly
+	if fetched {
+		SaveFeedValidators(url, etag, lastModified)
+	}
+	return feed, fetchedAt, nil
+}
+
+// downloadFeed does the conditional GET; a 304 returns a nil body
+func downloadFeed(ctx context.Context, url string) ([]byte, string, string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, "", "", err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; CryptoNewsIntel/3.0)")
+
+	etag, lastModified := GetFeedValidators(url)
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	resp, err := feedClient.Do(req)
+	if err != nil {
+		return nil, "", "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, "", "", nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", "", fmt.Errorf("feed returned status: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxArticleBytes))
 	if err != nil {
+		return nil, "", "", err
+	}
+	return body, resp.Header.Get("ETag"), resp.Header.Get("Last-Modified"), nil
+}
+
+// binanceAPISource reads Binance announcements from the CMS API
+type binanceAPISource struct {
+	name string
+}
+
+func newBinanceAPISource(cfg SourceConfig) (Source, error) {
+	return &binanceAPISource{name: cfg.Name}, nil
+}
+
+func (s *binanceAPISource) Name() string { return s.name }
+func (s *binanceAPISource) Kind() string { return KindBinanceAPI }
+
+func (s *binanceAPISource) Fetch(ctx context.Context) ([]NewsItem, error) {
+	return FetchBinanceBAPI(ctx)
+}
+
+// FetchRSS fetches news from a standard RSS feed.
+// An unchanged feed (304) yields no items.
+func FetchRSS(ctx context.Context, url string, sourceName string) ([]NewsItem, error) {
+	feed, fetchedAt, err := fetchFeed(ctx, url)
+	if err != nil || feed == nil {
 		return nil, err
 	}
 
@@ -25,22 +184,20 @@ func FetchRSS(url string, sourceName string) ([]NewsItem, error) {
 			break
 		}
 		
+		// Undated items get the fetch time (the recording time when replaying)
 		pubDate := item.PublishedParsed
 		if pubDate == nil {
-			now := time.Now()
-			pubDate = &now
+			pubDate = &fetchedAt
 		}
 
 		newsItem := NewsItem{
-			ID:        item.GUID,
-			Title:     item.Title,
-			Source:    sourceName,
-			Timestamp: *pubDate,
-		}
-		
-		// Fallback ID if GUID missing
-		if newsItem.ID == "" {
-			newsItem.ID = item.Link
+			ID:          CanonicalID(item.Link, sourceName, item.Title), // GUID formats change; links don't
+			Title:       item.Title,
+			Source:      sourceName,
+			Timestamp:   *pubDate,
+			Link:        item.Link,
+			Description: truncate(cleanText(item.Description), 1000),
+			Body:        truncate(cleanText(item.Content), maxBodyChars),
 		}
 
 		items = append(items, newsItem)
@@ -48,92 +205,138 @@ func FetchRSS(url string, sourceName string) ([]NewsItem, error) {
 	return items, nil
 }
 
-// FetchBinanceHeadless scans Binance using a real hidden browser
-func FetchBinanceHeadless() ([]NewsItem, error) {
-	// 1. Setup Chromedp Context (Stealth Mode)
-	opts := append(chromedp.DefaultExecAllocatorOptions[:],
-		chromedp.Flag("headless", true), 
-		chromedp.Flag("disable-gpu", true),
-		chromedp.Flag("enable-automation", false), // Key for stealth
-		chromedp.Flag("disable-blink-features", "AutomationControlled"), // Key for stealth
-		chromedp.UserAgent("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"),
-	)
-
-	allocCtx, cancel := chromedp.NewExecAllocator(context.Background(), opts...)
-	defer cancel()
-
-	ctx, cancel := chromedp.NewContext(allocCtx)
-	defer cancel()
-
-	// 2. Set timeout (60s)
-	ctx, cancel = context.WithTimeout(ctx, 60*time.Second)
-	defer cancel()
-
-	// 3. Navigate & Extract
-	// We use a broader wait and extraction strategy
-	var titles []string
-	err := chromedp.Run(ctx,
-		// Hide webdriver property
-		chromedp.ActionFunc(func(ctx context.Context) error {
-			err := chromedp.Evaluate(`Object.defineProperty(navigator, 'webdriver', {get: () => undefined})`, nil).Do(ctx)
-			return err
-		}),
-		chromedp.Navigate("https://www.binance.com/en/support/announcement/new-cryptocurrency-listing?c=48&navId=48"),
-		// Wait for ANY main content
-		chromedp.WaitVisible(`body`, chromedp.ByQuery),
-		chromedp.Sleep(5 * time.Second), 
-		// specific JS to grab the first few links that look like announcements
-		chromedp.Evaluate(`
-			Array.from(document.querySelectorAll('a'))
-				.filter(a => a.href.includes('/support/announcement/'))
-				.map(a => a.innerText)
-				.filter(t => t.length > 20 && !t.includes("View More"))
-				// deduplicate by text
-				.filter((v, i, a) => a.indexOf(v) === i)
-				.slice(0, 10)
-		`, &titles),
-	)
+// binanceHeadlessSpec grabs the first few links that look like announcements
+var binanceHeadlessSpec = ScrapeSpec{
+	Settle: "5s",
+	Script: `
+		Array.from(document.querySelectorAll('a'))
+			.filter(a => a.href.includes('/support/announcement/'))
+			.map(a => ({title: a.innerText, link: a.href}))
+			.filter(l => l.title.length > 20 && !l.title.includes("View More"))
+			// deduplicate by link
+			.filter((l, i, a) => a.findIndex(o => o.link === l.link) === i)
+			.slice(0, 10)
+	`,
+}
 
+// FetchBinanceHeadless scans Binance using a real hidden browser
+func FetchBinanceHeadless(ctx context.Context) ([]NewsItem, error) {
+	entries, err := ScrapeHeadless(ctx, "https://www.binance.com/en/support/announcement/new-cryptocurrency-listing?c=48&navId=48", binanceHeadlessSpec)
 	if err != nil {
-		return nil, fmt.Errorf("headless fetch failed: %v", err)
+		return nil, err
 	}
 
 	var items []NewsItem
-	for _, title := range titles {
+	for _, entry := range entries {
 		// Cleanup title
-		title = strings.TrimSpace(title)
-		if title == "" { continue }
+		title := strings.TrimSpace(entry.Title)
+		code := binanceCodeFromURL(entry.Link)
+		if title == "" || code == "" { continue }
 
+		// Rebuild the link from the code so it matches the BAPI collector's
+		link := binanceAnnouncementURL + code
 		items = append(items, NewsItem{
-			ID:        "binance-" + title, // Simple ID
+			ID:        CanonicalID(link, "Binance", title),
 			Title:     title,
 			Source:    "Binance",
-			Timestamp: time.Now(), // Approx time
+			Timestamp: parseScrapedDate(entry.Date, ""), // Page shows no date; replays carry the recording time
+			Link:      link,
 		})
 	}
 
 	return items, nil
 }
 
-// Fallback BAPI (Kept for legacy)
-func FetchBinanceBAPI() ([]NewsItem, error) {
+// binanceAnnouncementURL is the public page for an article code
+const binanceAnnouncementURL = "https://www.binance.com/en/support/announcement/"
+
+// binanceCodeFromURL pulls the article code out of an announcement link.
+// Handles both ".../announcement/detail/<code>" and ".../announcement/<slug>-<code>".
+func binanceCodeFromURL(href string) string {
+	href = strings.SplitN(href, "?", 2)[0]
+	href = strings.TrimRight(href, "/")
+	last := href[strings.LastIndex(href, "/")+1:]
+	if i := strings.LastIndex(last, "-"); i >= 0 {
+		last = last[i+1:]
+	}
+	return last
+}
+
+// bapiResponse is the subset of the Binance CMS list response we use
+type bapiResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		Catalogs []struct {
+			CatalogName string `json:"catalogName"`
+			Articles    []struct {
+				Code        string `json:"code"`
+				Title       string `json:"title"`
+				ReleaseDate int64  `json:"releaseDate"` // Unix millis
+			} `json:"articles"`
+		} `json:"catalogs"`
+	} `json:"data"`
+}
+
+// FetchBinanceBAPI reads the listing announcements straight from Binance's CMS API (no browser)
+func FetchBinanceBAPI(ctx context.Context) ([]NewsItem, error) {
 	url := "https://www.binance.com/bapi/composite/v1/public/cms/article/list/query"
 	payload := strings.NewReader(`{"type":"catalogs","catalogId":48,"pageNo":1,"pageSize":10}`)
 
-	req, _ := http.NewRequest("POST", url, payload)
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("User-Agent", "Mozilla/5.0")
+	data, fetchedAt, err := fetchPayload("binance-bapi", "json", func() ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", url, payload)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Add("Content-Type", "application/json")
+		req.Header.Add("User-Agent", "Mozilla/5.0")
+
+		client := &http.Client{Timeout: 10 * time.Second}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
 
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(req)
+		if resp.StatusCode != 200 {
+			return nil, fmt.Errorf("binance api returned status: %d", resp.StatusCode)
+		}
+		return io.ReadAll(io.LimitReader(resp.Body, maxArticleBytes))
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("binance api returned status: %d", resp.StatusCode)
+	var result bapiResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("binance api decode failed: %v", err)
+	}
+	if result.Code != "000000" {
+		return nil, fmt.Errorf("binance api error %s: %s", result.Code, result.Message)
+	}
+
+	var items []NewsItem
+	for _, catalog := range result.Data.Catalogs {
+		for _, article := range catalog.Articles {
+			title := strings.TrimSpace(article.Title)
+			if article.Code == "" || title == "" {
+				continue
+			}
+
+			ts := fetchedAt
+			if article.ReleaseDate > 0 {
+				ts = time.UnixMilli(article.ReleaseDate)
+			}
+
+			link := binanceAnnouncementURL + article.Code
+			items = append(items, NewsItem{
+				ID:        CanonicalID(link, "Binance", title),
+				Title:     title,
+				Source:    "Binance",
+				Timestamp: ts,
+				Link:      link,
+			})
+		}
 	}
-	// ... decoding omitted for brevity as unused ...
-	return nil, nil 
+	return items, nil
 }
@@ -0,0 +1,128 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// SourcesFile is the on-disk layout of the sources config
+type SourcesFile struct {
+	Sources []SourceConfig `json:"sources"`
+}
+
+// configWriteMu serializes every read-modify-write of the config files
+// (sources.json, assets.json) so concurrent imports can't drop each other's changes
+var configWriteMu sync.Mutex
+
+// SourcesConfigPath returns SOURCES_CONFIG or ./sources.json
+func SourcesConfigPath() string {
+	if p := os.Getenv("SOURCES_CONFIG"); p != "" {
+		return p
+	}
+	return "./sources.json"
+}
+
+// ExportSourceConfigs returns every source in the config file at path,
+// disabled ones included, or the built-in list when there is no file.
+// Both OPML exports use it, so an export is a full copy of the config.
+func ExportSourceConfigs(path string) ([]SourceConfig, error) {
+	cfgs, err := LoadSourcesConfig(path)
+	if os.IsNotExist(err) {
+		return DefaultSourceConfigs(), nil
+	}
+	return cfgs, err
+}
+
+// LoadSourcesConfig reads and validates a sources config file
+func LoadSourcesConfig(path string) ([]SourceConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file SourcesFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse %s: %v", path, err)
+	}
+
+	for i, cfg := range file.Sources {
+		if cfg.Name == "" || cfg.Type == "" {
+			return nil, fmt.Errorf("%s: source #%d needs a name and a type", path, i+1)
+		}
+		for field, value := range map[string]string{"interval": cfg.Interval, "timeout": cfg.Timeout, "max_backoff": cfg.MaxBackoff} {
+			if value == "" {
+				continue
+			}
+			if _, err := time.ParseDuration(value); err != nil {
+				return nil, fmt.Errorf("%s: source %s has bad %s %q", path, cfg.Name, field, value)
+			}
+		}
+		if cfg.Trust < 0 || cfg.Trust > 1 {
+			return nil, fmt.Errorf("%s: source %s trust must be between 0 and 1", path, cfg.Name)
+		}
+	}
+	return file.Sources, nil
+}
+
+// SaveSourcesConfig writes the source list back to disk (atomically, so the
+// hot-reload watcher never sees a half-written file)
+func SaveSourcesConfig(path string, cfgs []SourceConfig) error {
+	configWriteMu.Lock()
+	defer configWriteMu.Unlock()
+	return saveSourcesConfig(path, cfgs)
+}
+
+// saveSourcesConfig is SaveSourcesConfig for callers already holding configWriteMu
+func saveSourcesConfig(path string, cfgs []SourceConfig) error {
+	data, err := json.MarshalIndent(SourcesFile{Sources: cfgs}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// ReloadSources (re)builds the source registry from the config file.
+// A missing file falls back to the built-in list; an invalid one keeps
+// whatever is currently running.
+func ReloadSources(path string) error {
+	cfgs, err := LoadSourcesConfig(path)
+	if os.IsNotExist(err) {
+		log.Printf("Note: %s not found, using built-in sources", path)
+		cfgs, err = DefaultSourceConfigs(), nil
+	}
+	if err != nil {
+		return err
+	}
+
+	n := SetSources(cfgs)
+	fmt.Printf("📡 Loaded %d active sources from %s\n", n, path)
+	return nil
+}
+
+// WatchFile polls path for modifications and calls onChange after each one
+func WatchFile(path string, every time.Duration, onChange func()) {
+	var lastMod time.Time
+	if info, err := os.Stat(path); err == nil {
+		lastMod = info.ModTime()
+	}
+
+	for range time.Tick(every) {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if info.ModTime().After(lastMod) {
+			lastMod = info.ModTime()
+			onChange()
+		}
+	}
+}
@@ -3,6 +3,8 @@ package internal
 import (
 	"database/sql"
 	"log"
+	"os"
+	"strings"
 	"time"
 
 	_ "modernc.org/sqlite"
@@ -11,8 +13,14 @@ import (
 var DB *sql.DB
 
 func InitDB() {
+	// DB_PATH lets replays run against a scratch database
+	path := os.Getenv("DB_PATH")
+	if path == "" {
+		path = "./news.db"
+	}
+
 	var err error
-	DB, err = sql.Open("sqlite", "./news.db")
+	DB, err = sql.Open("sqlite", path)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -31,27 +39,255 @@ func InitDB() {
 		final_score REAL,
 		ai_analysis TEXT,
 		ai_advice TEXT,
-		coin_symbol TEXT
+		coin_symbol TEXT,
+		link TEXT DEFAULT '',
+		description TEXT DEFAULT '',
+		body TEXT DEFAULT '',
+		story_id TEXT DEFAULT '',
+		coverage INTEGER DEFAULT 1,
+		social INTEGER DEFAULT 0,
+		community TEXT DEFAULT '',
+		upvotes INTEGER DEFAULT 0,
+		comments INTEGER DEFAULT 0,
+		category TEXT DEFAULT '',
+		language TEXT DEFAULT '',
+		title_en TEXT DEFAULT '',
+		sentiment_note TEXT DEFAULT '',
+		trust REAL DEFAULT 0
 	);`
 
 	_, err = DB.Exec(createTableSQL)
 	if err != nil {
 		log.Fatal("Failed to create table:", err)
 	}
+
+	// Columns added after the first release (older databases lack them)
+	ensureColumn("news_items", "link", "TEXT DEFAULT ''")
+	ensureColumn("news_items", "description", "TEXT DEFAULT ''")
+	ensureColumn("news_items", "body", "TEXT DEFAULT ''")
+	ensureColumn("news_items", "story_id", "TEXT DEFAULT ''")
+	ensureColumn("news_items", "coverage", "INTEGER DEFAULT 1")
+	ensureColumn("news_items", "social", "INTEGER DEFAULT 0")
+	ensureColumn("news_items", "community", "TEXT DEFAULT ''")
+	ensureColumn("news_items", "upvotes", "INTEGER DEFAULT 0")
+	ensureColumn("news_items", "comments", "INTEGER DEFAULT 0")
+	ensureColumn("news_items", "category", "TEXT DEFAULT ''")
+	ensureColumn("news_items", "language", "TEXT DEFAULT ''")
+	ensureColumn("news_items", "title_en", "TEXT DEFAULT ''")
+	ensureColumn("news_items", "sentiment_note", "TEXT DEFAULT ''")
+	ensureColumn("news_items", "trust", "REAL DEFAULT 0")
+
+	// Every item ID ever ingested, so old stories still in a feed are never re-processed
+	_, err = DB.Exec(`CREATE TABLE IF NOT EXISTS seen_items (
+		id TEXT PRIMARY KEY,
+		first_seen DATETIME
+	);`)
+	if err != nil {
+		log.Fatal("Failed to create seen_items table:", err)
+	}
+	migrateCanonicalIDs()
+
+	// Asset tags: many-to-many between news_items and asset symbols
+	_, err = DB.Exec(`CREATE TABLE IF NOT EXISTS news_assets (
+		news_id TEXT,
+		symbol TEXT,
+		relevance REAL,
+		PRIMARY KEY (news_id, symbol)
+	);
+	CREATE INDEX IF NOT EXISTS idx_news_assets_symbol ON news_assets(symbol);`)
+	if err != nil {
+		log.Fatal("Failed to create news_assets table:", err)
+	}
+	// Items saved before tagging keep their single asset
+	_, err = DB.Exec(`INSERT OR IGNORE INTO news_assets(news_id, symbol, relevance)
+		SELECT id, asset, 1.0 FROM news_items WHERE asset NOT IN ('', 'ALT', 'ALL')`)
+	if err != nil {
+		log.Fatal("Failed to seed news_assets:", err)
+	}
+
+	// Databases from before the index already know these
+	_, err = DB.Exec("INSERT OR IGNORE INTO seen_items(id, first_seen) SELECT id, timestamp FROM news_items")
+	if err != nil {
+		log.Fatal("Failed to seed seen_items:", err)
+	}
+
+	// HTTP validators for conditional feed requests
+	_, err = DB.Exec(`CREATE TABLE IF NOT EXISTS feed_cache (
+		url TEXT PRIMARY KEY,
+		etag TEXT,
+		last_modified TEXT,
+		updated_at DATETIME
+	);`)
+	if err != nil {
+		log.Fatal("Failed to create feed_cache table:", err)
+	}
+
+	// Per-source fetch health (survives restarts)
+	_, err = DB.Exec(`CREATE TABLE IF NOT EXISTS source_health (
+		name TEXT PRIMARY KEY,
+		kind TEXT,
+		last_success DATETIME,
+		last_error TEXT,
+		last_error_at DATETIME,
+		consecutive_failures INTEGER,
+		last_item_count INTEGER,
+		last_latency_ms INTEGER,
+		total_fetches INTEGER,
+		total_failures INTEGER
+	);`)
+	if err != nil {
+		log.Fatal("Failed to create source_health table:", err)
+	}
 }
 
+// ensureColumn adds a column to an existing table if it is missing
+func ensureColumn(table, column, decl string) {
+	rows, err := DB.Query("PRAGMA table_info(" + table + ")")
+	if err != nil {
+		log.Fatal("Failed to inspect table:", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid, notNull, pk int
+			name, colType    string
+			dflt             sql.NullString
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dflt, &pk); err == nil && name == column {
+			return
+		}
+	}
+	rows.Close()
+
+	if _, err := DB.Exec("ALTER TABLE " + table + " ADD COLUMN " + column + " " + decl); err != nil {
+		log.Fatal("Failed to add column "+column+":", err)
+	}
+}
+
+// migrateCanonicalIDs re-keys rows saved under feed GUIDs / old Binance IDs
+// to CanonicalID. Runs once, tracked by PRAGMA user_version.
+func migrateCanonicalIDs() {
+	var version int
+	if err := DB.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		log.Fatal("Failed to read schema version:", err)
+	}
+	if version >= 1 {
+		return
+	}
+
+	rows, err := DB.Query("SELECT id, link, source, title FROM news_items ORDER BY timestamp ASC")
+	if err != nil {
+		log.Fatal("Failed to read news_items for migration:", err)
+	}
+	remap := make(map[string]string)
+	backfill := make(map[string]bool) // Rows stored before links were: their URL ID is the link
+	legacy := make(map[string]string) // Rows with no link at all ("binance-" + title): their legacy seen key
+	var order []string
+	for rows.Next() {
+		var id, link, source, title sql.NullString
+		if err := rows.Scan(&id, &link, &source, &title); err != nil {
+			continue
+		}
+		// Rows from before the link column have link = '' but were keyed by
+		// their URL, which is what fresh fetches will hash
+		if link.String == "" && (strings.HasPrefix(id.String, "http://") || strings.HasPrefix(id.String, "https://")) {
+			link.String = id.String
+			backfill[id.String] = true
+		}
+		if link.String == "" {
+			legacy[id.String] = legacySeenID(source.String, title.String)
+		}
+		remap[id.String] = CanonicalID(link.String, source.String, title.String)
+		order = append(order, id.String)
+	}
+	rows.Close()
+
+	tx, err := DB.Begin()
+	if err != nil {
+		log.Fatal("Failed to start migration:", err)
+	}
+	rekeyed, dropped := 0, 0
+	taken := make(map[string]bool)
+	for _, oldID := range order {
+		newID := remap[oldID]
+		// Fresh fetches of these rows must count as seen, whichever key they carry
+		for _, seenID := range []string{oldID, newID, legacy[oldID]} {
+			if seenID == "" {
+				continue
+			}
+			if _, err := tx.Exec("INSERT OR IGNORE INTO seen_items(id, first_seen) VALUES(?, ?)", seenID, time.Now()); err != nil {
+				tx.Rollback()
+				log.Fatal("Failed to backfill seen_items:", err)
+			}
+		}
+		if taken[newID] {
+			// Same article stored twice under different GUIDs: keep the oldest
+			if _, err := tx.Exec("DELETE FROM news_items WHERE id = ?", oldID); err != nil {
+				tx.Rollback()
+				log.Fatal("Failed to drop duplicate row:", err)
+			}
+			dropped++
+			continue
+		}
+		taken[newID] = true
+		if backfill[oldID] {
+			if _, err := tx.Exec("UPDATE news_items SET link = ? WHERE id = ?", oldID, oldID); err != nil {
+				tx.Rollback()
+				log.Fatal("Failed to backfill link:", err)
+			}
+		}
+		if newID == oldID {
+			continue
+		}
+		if _, err := tx.Exec("UPDATE news_items SET id = ? WHERE id = ?", newID, oldID); err != nil {
+			tx.Rollback()
+			log.Fatal("Failed to re-key row:", err)
+		}
+		rekeyed++
+	}
+	for oldID, newID := range remap {
+		if oldID == newID {
+			continue
+		}
+		if _, err := tx.Exec("UPDATE news_items SET story_id = ? WHERE story_id = ?", newID, oldID); err != nil {
+			tx.Rollback()
+			log.Fatal("Failed to remap story IDs:", err)
+		}
+	}
+	if _, err := tx.Exec("PRAGMA user_version = 1"); err != nil {
+		tx.Rollback()
+		log.Fatal("Failed to bump schema version:", err)
+	}
+	if err := tx.Commit(); err != nil {
+		log.Fatal("Failed to commit migration:", err)
+	}
+	if rekeyed > 0 || dropped > 0 {
+		log.Printf("🔑 Migrated item IDs: %d re-keyed, %d duplicates dropped", rekeyed, dropped)
+	}
+}
+
+// newsColumns is the column list shared by SaveNewsItem and GetLatestNews
+const newsColumns = `id, title, source, scope, asset, impact, sentiment, timestamp,
+		trading_signal, rule_reason, final_score, ai_analysis, ai_advice, coin_symbol,
+		link, description, body, story_id, coverage,
+		social, community, upvotes, comments, category, language, title_en,
+		sentiment_note, trust`
+
 // SaveNewsItem inserts or updates a news item
 func SaveNewsItem(item NewsItem) {
-	stmt, err := DB.Prepare(`INSERT INTO news_items(
-		id, title, source, scope, asset, impact, sentiment, timestamp,
-		trading_signal, rule_reason, final_score, ai_analysis, ai_advice, coin_symbol
-	) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	stmt, err := DB.Prepare(`INSERT INTO news_items(` + newsColumns + `
+	) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	ON CONFLICT(id) DO UPDATE SET
 		ai_analysis=excluded.ai_analysis,
 		ai_advice=excluded.ai_advice,
 		coin_symbol=excluded.coin_symbol,
 		final_score=excluded.final_score,
-		trading_signal=excluded.trading_signal
+		trading_signal=excluded.trading_signal,
+		story_id=excluded.story_id,
+		coverage=excluded.coverage,
+		upvotes=excluded.upvotes,
+		comments=excluded.comments
 	;`)
 	if err != nil {
 		log.Println("DB Prepare Error:", err)
@@ -64,15 +300,78 @@ func SaveNewsItem(item NewsItem) {
 		item.Impact, item.Sentiment, item.Timestamp,
 		item.TradingSignal, item.RuleReason, item.FinalScore,
 		item.AIAnalysis, item.AIAdvice, item.CoinSymbol,
+		item.Link, item.Description, item.Body, item.StoryID, item.Coverage,
+		item.Social, item.Community, item.Upvotes, item.Comments, item.Category,
+		item.Language, item.TitleEN, item.SentimentNote, item.Trust,
 	)
 	if err != nil {
 		log.Println("DB Save Error:", err)
+		return
+	}
+	saveNewsAssets(item)
+}
+
+// saveNewsAssets replaces an item's asset tags
+func saveNewsAssets(item NewsItem) {
+	tx, err := DB.Begin()
+	if err != nil {
+		log.Println("DB Save Error:", err)
+		return
+	}
+	if _, err := tx.Exec("DELETE FROM news_assets WHERE news_id = ?", item.ID); err != nil {
+		tx.Rollback()
+		log.Println("DB Save Error:", err)
+		return
+	}
+	for _, tag := range item.Assets {
+		if _, err := tx.Exec("INSERT OR REPLACE INTO news_assets(news_id, symbol, relevance) VALUES(?, ?, ?)",
+			item.ID, tag.Symbol, tag.Relevance); err != nil {
+			tx.Rollback()
+			log.Println("DB Save Error:", err)
+			return
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		log.Println("DB Save Error:", err)
 	}
 }
 
 // GetLatestNews retrieves the last N items (for startup)
 func GetLatestNews(limit int) []NewsItem {
-	rows, err := DB.Query("SELECT * FROM news_items ORDER BY timestamp DESC LIMIT ?", limit)
+	return queryNews("SELECT "+newsColumns+" FROM news_items ORDER BY timestamp DESC LIMIT ?", limit)
+}
+
+// GetNewsItem loads one stored item by ID
+func GetNewsItem(id string) (NewsItem, bool) {
+	items := queryNews("SELECT "+newsColumns+" FROM news_items WHERE id = ?", id)
+	if len(items) == 0 {
+		return NewsItem{}, false
+	}
+	return items[0], true
+}
+
+// GetNewsByAsset returns the newest items tagged with any of the symbols,
+// one per story
+func GetNewsByAsset(symbols []string, limit int) []NewsItem {
+	if len(symbols) == 0 {
+		return nil
+	}
+	args := make([]interface{}, 0, len(symbols)+1)
+	for _, s := range symbols {
+		args = append(args, strings.ToUpper(s))
+	}
+	args = append(args, limit)
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(symbols)), ", ")
+	return queryNews(`SELECT `+newsColumns+` FROM news_items
+		WHERE id IN (SELECT news_id FROM news_assets WHERE symbol IN (`+placeholders+`))
+		AND (story_id = '' OR story_id = id)
+		ORDER BY timestamp DESC LIMIT ?`, args...)
+}
+
+// queryNews runs a SELECT of newsColumns and attaches each item's asset tags
+func queryNews(query string, args ...interface{}) []NewsItem {
+	rows, err := DB.Query(query, args...)
 	if err != nil {
 		log.Println("DB Query Error:", err)
 		return nil
@@ -88,6 +387,9 @@ func GetLatestNews(limit int) []NewsItem {
 			&item.Impact, &item.Sentiment, &ts,
 			&item.TradingSignal, &item.RuleReason, &item.FinalScore,
 			&item.AIAnalysis, &item.AIAdvice, &item.CoinSymbol,
+			&item.Link, &item.Description, &item.Body, &item.StoryID, &item.Coverage,
+			&item.Social, &item.Community, &item.Upvotes, &item.Comments, &item.Category,
+			&item.Language, &item.TitleEN, &item.SentimentNote, &item.Trust,
 		)
 		if err != nil {
 			continue
@@ -95,5 +397,146 @@ func GetLatestNews(limit int) []NewsItem {
 		item.Timestamp = ts
 		items = append(items, item)
 	}
+	rows.Close()
+
+	loadNewsAssets(items)
 	return items
 }
+
+// loadNewsAssets fills in Assets for a batch of items, most relevant first
+func loadNewsAssets(items []NewsItem) {
+	if len(items) == 0 {
+		return
+	}
+	index := make(map[string]int, len(items))
+	args := make([]interface{}, 0, len(items))
+	for i, item := range items {
+		index[item.ID] = i
+		args = append(args, item.ID)
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(items)), ", ")
+	rows, err := DB.Query(`SELECT news_id, symbol, relevance FROM news_assets
+		WHERE news_id IN (`+placeholders+`) ORDER BY relevance DESC, symbol`, args...)
+	if err != nil {
+		log.Println("DB Query Error:", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var tag AssetTag
+		if err := rows.Scan(&id, &tag.Symbol, &tag.Relevance); err != nil {
+			continue
+		}
+		if i, ok := index[id]; ok {
+			items[i].Assets = append(items[i].Assets, tag)
+		}
+	}
+}
+
+// IsSeen reports whether an item ID was already ingested
+func IsSeen(id string) bool {
+	var one int
+	err := DB.QueryRow("SELECT 1 FROM seen_items WHERE id = ?", id).Scan(&one)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println("DB Query Error:", err)
+	}
+	return err == nil
+}
+
+// IsSeenItem is IsSeen for a fetched item. It also recognizes items stored
+// before links were recorded (old "binance-" + title rows): the migration
+// left a source + title key for them, since their canonical ID can't be
+// rebuilt without the link.
+func IsSeenItem(item NewsItem) bool {
+	return IsSeen(item.ID) || IsSeen(legacySeenID(item.Source, item.Title))
+}
+
+// legacySeenID is the seen_items key of a row migrated without a link
+func legacySeenID(source, title string) string {
+	return "legacy-" + CanonicalID("", source, title)
+}
+
+// MarkSeen records an item ID and reports whether it was new.
+// On DB errors it returns true so the item isn't lost.
+func MarkSeen(id string) bool {
+	res, err := DB.Exec("INSERT OR IGNORE INTO seen_items(id, first_seen) VALUES(?, ?)", id, time.Now())
+	if err != nil {
+		log.Println("DB Save Error:", err)
+		return true
+	}
+	n, err := res.RowsAffected()
+	return err != nil || n > 0
+}
+
+// GetFeedValidators returns the stored ETag / Last-Modified for a feed URL
+func GetFeedValidators(url string) (etag, lastModified string) {
+	err := DB.QueryRow("SELECT etag, last_modified FROM feed_cache WHERE url = ?", url).Scan(&etag, &lastModified)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println("DB Query Error:", err)
+	}
+	return etag, lastModified
+}
+
+// SaveFeedValidators remembers the validators from the last full feed response
+func SaveFeedValidators(url, etag, lastModified string) {
+	_, err := DB.Exec(`INSERT INTO feed_cache(url, etag, last_modified, updated_at) VALUES(?, ?, ?, ?)
+	ON CONFLICT(url) DO UPDATE SET
+		etag=excluded.etag,
+		last_modified=excluded.last_modified,
+		updated_at=excluded.updated_at
+	;`, url, etag, lastModified, time.Now())
+	if err != nil {
+		log.Println("DB Save Error:", err)
+	}
+}
+
+// SaveSourceHealth upserts the health row for a source
+func SaveSourceHealth(h SourceHealth) {
+	_, err := DB.Exec(`INSERT INTO source_health(
+		name, kind, last_success, last_error, last_error_at, consecutive_failures,
+		last_item_count, last_latency_ms, total_fetches, total_failures
+	) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT(name) DO UPDATE SET
+		kind=excluded.kind,
+		last_success=excluded.last_success,
+		last_error=excluded.last_error,
+		last_error_at=excluded.last_error_at,
+		consecutive_failures=excluded.consecutive_failures,
+		last_item_count=excluded.last_item_count,
+		last_latency_ms=excluded.last_latency_ms,
+		total_fetches=excluded.total_fetches,
+		total_failures=excluded.total_failures
+	;`,
+		h.Name, h.Kind, h.LastSuccess, h.LastError, h.LastErrorAt, h.ConsecutiveFailures,
+		h.LastItemCount, h.LastLatencyMs, h.TotalFetches, h.TotalFailures,
+	)
+	if err != nil {
+		log.Println("DB Save Error:", err)
+	}
+}
+
+// GetSavedSourceHealth loads all persisted health rows
+func GetSavedSourceHealth() []SourceHealth {
+	rows, err := DB.Query(`SELECT name, kind, last_success, last_error, last_error_at, consecutive_failures,
+		last_item_count, last_latency_ms, total_fetches, total_failures FROM source_health`)
+	if err != nil {
+		log.Println("DB Query Error:", err)
+		return nil
+	}
+	defer rows.Close()
+
+	var list []SourceHealth
+	for rows.Next() {
+		var h SourceHealth
+		err = rows.Scan(&h.Name, &h.Kind, &h.LastSuccess, &h.LastError, &h.LastErrorAt, &h.ConsecutiveFailures,
+			&h.LastItemCount, &h.LastLatencyMs, &h.TotalFetches, &h.TotalFailures)
+		if err != nil {
+			continue
+		}
+		list = append(list, h)
+	}
+	return list
+}
@@ -0,0 +1,111 @@
+package internal
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// A database from the first release: rows keyed by their URL, no link column
+func TestMigrateCanonicalIDsFromURLKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "news.db")
+	old, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = old.Exec(`CREATE TABLE news_items (
+		id TEXT PRIMARY KEY, title TEXT, source TEXT, scope TEXT, asset TEXT,
+		impact REAL, sentiment REAL, timestamp DATETIME, trading_signal TEXT,
+		rule_reason TEXT, final_score REAL, ai_analysis TEXT, ai_advice TEXT, coin_symbol TEXT
+	)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldID := "https://www.coindesk.com/markets/btc-rally/?utm_source=rss"
+	if _, err := old.Exec("INSERT INTO news_items(id, title, source, timestamp) VALUES(?, ?, ?, ?)",
+		oldID, "Bitcoin rallies", "CoinDesk", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	old.Close()
+
+	t.Setenv("DB_PATH", path)
+	InitDB()
+	defer DB.Close()
+
+	// A fresh fetch of the same story must land on the migrated row
+	want := CanonicalID(oldID, "CoinDesk", "Bitcoin rallies")
+	var id, link string
+	if err := DB.QueryRow("SELECT id, link FROM news_items").Scan(&id, &link); err != nil {
+		t.Fatal(err)
+	}
+	if id != want {
+		t.Errorf("id = %q, want %q", id, want)
+	}
+	if link != oldID {
+		t.Errorf("link = %q, want the old URL ID %q", link, oldID)
+	}
+	if !IsSeen(want) {
+		t.Errorf("migrated ID %q not marked seen", want)
+	}
+}
+
+// Engagement re-read on a later poll must overwrite the first snapshot
+func TestSaveNewsItemUpdatesEngagement(t *testing.T) {
+	t.Setenv("DB_PATH", filepath.Join(t.TempDir(), "news.db"))
+	InitDB()
+	defer DB.Close()
+
+	item := NewsItem{ID: "post-1", Title: "ETH to the moon", Source: "Reddit", Social: true, Upvotes: 2, Timestamp: time.Now()}
+	SaveNewsItem(item)
+	item.Upvotes, item.Comments = 850, 120
+	SaveNewsItem(item)
+
+	got, ok := GetNewsItem("post-1")
+	if !ok {
+		t.Fatal("item not found")
+	}
+	if got.Upvotes != 850 || got.Comments != 120 {
+		t.Errorf("engagement = %d upvotes, %d comments; want 850, 120", got.Upvotes, got.Comments)
+	}
+}
+
+// The old Binance collector keyed rows by "binance-" + title and stored no
+// link; fetching the same announcement with its link must not re-admit it
+func TestMigrateCanonicalIDsLegacyBinance(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "news.db")
+	old, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = old.Exec(`CREATE TABLE news_items (
+		id TEXT PRIMARY KEY, title TEXT, source TEXT, scope TEXT, asset TEXT,
+		impact REAL, sentiment REAL, timestamp DATETIME, trading_signal TEXT,
+		rule_reason TEXT, final_score REAL, ai_analysis TEXT, ai_advice TEXT, coin_symbol TEXT
+	)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	title := "Binance Will List Pepe (PEPE)"
+	if _, err := old.Exec("INSERT INTO news_items(id, title, source, timestamp) VALUES(?, ?, ?, ?)",
+		"binance-"+title, title, "Binance", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	old.Close()
+
+	t.Setenv("DB_PATH", path)
+	InitDB()
+	defer DB.Close()
+
+	if !IsSeen("binance-" + title) {
+		t.Error("old ID not in seen_items")
+	}
+	link := "https://www.binance.com/en/support/announcement/abc123"
+	fetched := NewsItem{ID: CanonicalID(link, "Binance", title), Link: link, Source: "Binance", Title: title}
+	if !IsSeenItem(fetched) {
+		t.Errorf("refetched announcement %q not recognized as seen", fetched.ID)
+	}
+	if IsSeenItem(NewsItem{ID: CanonicalID(link+"x", "Binance", "Another"), Source: "Binance", Title: "Another"}) {
+		t.Error("unrelated item reported as seen")
+	}
+}
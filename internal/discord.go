@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// DiscordMessage is a Discord webhook payload, as forwarded by a relay
+// (a bot or a followed announcement channel pointed at our endpoint)
+type DiscordMessage struct {
+	Username  string         `json:"username"`
+	Content   string         `json:"content"`
+	Timestamp string         `json:"timestamp"`
+	Embeds    []DiscordEmbed `json:"embeds"`
+}
+
+// DiscordEmbed is a rich embed; announcement bots usually put the news here
+type DiscordEmbed struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	URL         string `json:"url"`
+	Timestamp   string `json:"timestamp"`
+}
+
+// ParseDiscordMessage turns a relayed message into NewsItems: one per embed,
+// or one for the plain content. channel names the Source; the payload's
+// username (the relay bot's display name) is only a fallback, so a channel's
+// items keep one source name whichever bot relays them.
+func ParseDiscordMessage(r io.Reader, channel string) ([]NewsItem, error) {
+	var msg DiscordMessage
+	if err := json.NewDecoder(r).Decode(&msg); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+
+	source := strings.TrimSpace(channel)
+	if source == "" {
+		source = strings.TrimSpace(msg.Username)
+	}
+	if source == "" {
+		return nil, fmt.Errorf("no channel: set ?channel= (or username)")
+	}
+
+	var items []NewsItem
+	for _, embed := range msg.Embeds {
+		text := strings.TrimSpace(embed.Title + "\n" + embed.Description)
+		if text == "" {
+			continue
+		}
+		items = append(items, discordItem(source, messageTitle(text), embed.Description, embed.URL, firstNonEmpty(embed.Timestamp, msg.Timestamp)))
+	}
+	if len(items) == 0 && strings.TrimSpace(msg.Content) != "" {
+		items = append(items, discordItem(source, messageTitle(msg.Content), msg.Content, "", msg.Timestamp))
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("message has no text")
+	}
+	return items, nil
+}
+
+func discordItem(source, title, text, link, timestamp string) NewsItem {
+	ts := time.Now()
+	if parsed, err := time.Parse(time.RFC3339, timestamp); err == nil && !parsed.After(ts.Add(time.Hour)) {
+		ts = parsed
+	}
+	return NewsItem{
+		ID:          CanonicalID(link, source, title),
+		Title:       title,
+		Source:      source,
+		Timestamp:   ts,
+		Link:        link,
+		Description: truncate(collapseSpaces(text), 1000),
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,30 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDiscordMessageSource(t *testing.T) {
+	payload := `{"username": "Announcement Bot", "embeds": [{"title": "Binance Will List Pepe (PEPE)", "url": "https://www.binance.com/en/support/announcement/abc"}]}`
+
+	tests := []struct {
+		channel string
+		want    string
+	}{
+		{"binance-announcements", "binance-announcements"},
+		{"", "Announcement Bot"},
+	}
+	for _, tt := range tests {
+		items, err := ParseDiscordMessage(strings.NewReader(payload), tt.channel)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(items) != 1 {
+			t.Fatalf("channel %q: got %d items, want 1", tt.channel, len(items))
+		}
+		if items[0].Source != tt.want {
+			t.Errorf("channel %q: source %q, want %q", tt.channel, items[0].Source, tt.want)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	maxArticleBytes = 2 << 20 // Don't read more than 2MB of HTML
+	maxBodyChars    = 20000
+	minParagraphLen = 40 // Shorter <p> blocks are usually captions / bylines
+)
+
+var articleClient = &http.Client{Timeout: 20 * time.Second}
+
+// ExtractArticle downloads an article page and returns its cleaned body text.
+// It is a small readability-style heuristic: strip page chrome, then keep the
+// container holding the most paragraph text.
+func ExtractArticle(ctx context.Context, url string) (string, error) {
+	data, _, err := fetchPayload(url, "html", func() ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+
+		resp, err := articleClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("article returned status: %d", resp.StatusCode)
+		}
+		return io.ReadAll(io.LimitReader(resp.Body, maxArticleBytes))
+	})
+	if err != nil {
+		return "", err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	return extractBody(doc), nil
+}
+
+func extractBody(doc *goquery.Document) string {
+	doc.Find("script, style, noscript, nav, header, footer, aside, form, iframe, svg").Remove()
+
+	// Score each paragraph's parent by how much real text it holds
+	var best *goquery.Selection
+	bestScore := 0
+	scores := make(map[string]int)
+
+	doc.Find("p").Each(func(_ int, p *goquery.Selection) {
+		text := collapseSpaces(p.Text())
+		if len(text) < minParagraphLen {
+			return
+		}
+		parent := p.Parent()
+		key := nodeKey(parent)
+		scores[key] += len(text)
+		if scores[key] > bestScore {
+			best, bestScore = parent, scores[key]
+		}
+	})
+
+	if best == nil {
+		return ""
+	}
+
+	var paragraphs []string
+	best.Find("p").Each(func(_ int, p *goquery.Selection) {
+		if text := collapseSpaces(p.Text()); len(text) >= minParagraphLen {
+			paragraphs = append(paragraphs, text)
+		}
+	})
+	return truncate(strings.Join(paragraphs, "\n\n"), maxBodyChars)
+}
+
+// nodeKey identifies a DOM node so paragraphs sharing a parent are grouped
+func nodeKey(s *goquery.Selection) string {
+	if len(s.Nodes) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%p", s.Nodes[0])
+}
+
+// cleanText strips markup from a feed field (description, content:encoded)
+func cleanText(html string) string {
+	if html == "" {
+		return ""
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return collapseSpaces(html)
+	}
+	doc.Find("script, style").Remove()
+	return collapseSpaces(doc.Text())
+}
+
+func collapseSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func truncate(s string, max int) string {
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	return string(r[:max])
+}
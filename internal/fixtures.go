@@ -0,0 +1,122 @@
+package internal
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Collector modes, set with COLLECTOR_MODE
+const (
+	ModeLive   = ""       // Talk to the internet (default)
+	ModeRecord = "record" // Talk to the internet and save every raw payload
+	ModeReplay = "replay" // Serve saved payloads instead of fetching
+)
+
+// fixtureTimeLayout names recordings so they sort chronologically
+const fixtureTimeLayout = "20060102T150405.000Z"
+
+// CollectorMode returns the configured collector mode
+func CollectorMode() string {
+	return strings.ToLower(strings.TrimSpace(os.Getenv("COLLECTOR_MODE")))
+}
+
+// FixturesDir is where recordings live (FIXTURES_DIR, default ./fixtures)
+func FixturesDir() string {
+	if dir := os.Getenv("FIXTURES_DIR"); dir != "" {
+		return dir
+	}
+	return "./fixtures"
+}
+
+// replayCursor remembers which recording each key served last
+var (
+	replayMu     sync.Mutex
+	replayCursor = make(map[string]int)
+)
+
+// fetchPayload wraps a collector's raw download. Live mode just calls fetch;
+// record mode also saves non-empty payloads under FIXTURES_DIR/<key>/<time>.<ext>;
+// replay mode never calls fetch and serves the recordings for key in order,
+// repeating the last one once they run out. It also returns when the payload
+// was fetched, so replayed items can keep their original timing.
+func fetchPayload(key, ext string, fetch func() ([]byte, error)) ([]byte, time.Time, error) {
+	key = fixtureKey(key)
+
+	switch CollectorMode() {
+	case ModeReplay:
+		return replayPayload(key)
+
+	case ModeRecord:
+		data, err := fetch()
+		now := time.Now().UTC()
+		if err == nil && len(data) > 0 {
+			if err := recordPayload(key, ext, now, data); err != nil {
+				log.Printf("⚠️  Could not record %s: %v", key, err)
+			}
+		}
+		return data, now, err
+
+	default:
+		data, err := fetch()
+		return data, time.Now(), err
+	}
+}
+
+func recordPayload(key, ext string, at time.Time, data []byte) error {
+	dir := filepath.Join(FixturesDir(), key)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, at.Format(fixtureTimeLayout)+"."+ext), data, 0o644)
+}
+
+func replayPayload(key string) ([]byte, time.Time, error) {
+	dir := filepath.Join(FixturesDir(), key)
+	files, err := filepath.Glob(filepath.Join(dir, "*.*"))
+	if err != nil || len(files) == 0 {
+		return nil, time.Time{}, fmt.Errorf("no recordings for %s in %s", key, FixturesDir())
+	}
+	sort.Strings(files)
+
+	replayMu.Lock()
+	i := replayCursor[key]
+	if i >= len(files) {
+		i = len(files) - 1
+	}
+	replayCursor[key] = i + 1
+	replayMu.Unlock()
+
+	data, err := os.ReadFile(files[i])
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	name := filepath.Base(files[i])
+	at, err := time.Parse(fixtureTimeLayout, strings.TrimSuffix(name, filepath.Ext(name)))
+	if err != nil {
+		at = time.Now()
+	}
+	return data, at, nil
+}
+
+var unsafeKeyChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// fixtureKey turns a URL or name into a readable, collision-free directory name
+func fixtureKey(raw string) string {
+	readable := raw
+	if i := strings.Index(readable, "://"); i >= 0 {
+		readable = readable[i+3:]
+	}
+	readable = strings.Trim(unsafeKeyChars.ReplaceAllString(readable, "-"), "-.")
+	if len(readable) > 60 {
+		readable = readable[:60]
+	}
+	return readable + "-" + shortHash(raw)[:8]
+}
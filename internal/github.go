@@ -0,0 +1,176 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// githubSource polls release feeds (and, with GITHUB_TOKEN, security
+// advisories) of protocol repos such as ethereum/go-ethereum
+type githubSource struct {
+	name  string
+	repos map[string]string // "owner/repo" -> asset symbol
+	order []string          // Stable polling order
+}
+
+func newGitHubSource(cfg SourceConfig) (Source, error) {
+	if len(cfg.Repos) == 0 {
+		return nil, fmt.Errorf("github source %s needs repos", cfg.Name)
+	}
+	s := &githubSource{name: cfg.Name, repos: make(map[string]string)}
+	for repo, asset := range cfg.Repos {
+		repo = strings.Trim(strings.TrimSpace(repo), "/")
+		if strings.Count(repo, "/") != 1 {
+			return nil, fmt.Errorf("github source %s: repo %q must look like owner/name", cfg.Name, repo)
+		}
+		s.repos[repo] = strings.ToUpper(strings.TrimSpace(asset))
+		s.order = append(s.order, repo)
+	}
+	sort.Strings(s.order)
+	return s, nil
+}
+
+func (s *githubSource) Name() string { return s.name }
+func (s *githubSource) Kind() string { return KindGitHub }
+
+// Fetch reads every repo; one broken repo doesn't fail the others
+func (s *githubSource) Fetch(ctx context.Context) ([]NewsItem, error) {
+	token := os.Getenv("GITHUB_TOKEN")
+
+	var items []NewsItem
+	var firstErr error
+	for _, repo := range s.order {
+		releases, err := s.fetchReleases(ctx, repo)
+		if err == nil && token != "" {
+			var advisories []NewsItem
+			advisories, err = s.fetchAdvisories(ctx, repo, token)
+			releases = append(releases, advisories...)
+		}
+		if err != nil {
+			log.Printf("⚠️  GitHub %s: %v", repo, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		items = append(items, releases...)
+	}
+
+	if len(items) == 0 && firstErr != nil {
+		return nil, firstErr
+	}
+	return items, nil
+}
+
+// fetchReleases reads github.com/<repo>/releases.atom (no API quota needed)
+func (s *githubSource) fetchReleases(ctx context.Context, repo string) ([]NewsItem, error) {
+	feed, fetchedAt, err := fetchFeed(ctx, "https://github.com/"+repo+"/releases.atom")
+	if err != nil || feed == nil {
+		return nil, err
+	}
+
+	project := repo[strings.Index(repo, "/")+1:]
+	var items []NewsItem
+	for _, entry := range feed.Items {
+		if len(items) >= 5 {
+			break
+		}
+
+		ts := fetchedAt
+		if entry.PublishedParsed != nil {
+			ts = *entry.PublishedParsed
+		} else if entry.UpdatedParsed != nil {
+			ts = *entry.UpdatedParsed
+		}
+
+		title := collapseSpaces(project + " release " + entry.Title)
+		items = append(items, NewsItem{
+			ID:        CanonicalID(entry.Link, s.name, title),
+			Title:     title,
+			Source:    s.name,
+			Timestamp: ts,
+			Link:      entry.Link,
+			Body:      truncate(cleanText(entry.Content), maxBodyChars),
+			AssetHint: s.repos[repo],
+			Category:  CategoryRelease,
+		})
+	}
+	return items, nil
+}
+
+// githubAdvisory is the part of the repository advisories API we use
+type githubAdvisory struct {
+	GHSAID      string    `json:"ghsa_id"`
+	CVEID       string    `json:"cve_id"`
+	HTMLURL     string    `json:"html_url"`
+	Summary     string    `json:"summary"`
+	Description string    `json:"description"`
+	Severity    string    `json:"severity"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// fetchAdvisories lists the repo's published security advisories
+func (s *githubSource) fetchAdvisories(ctx context.Context, repo, token string) ([]NewsItem, error) {
+	url := "https://api.github.com/repos/" + repo + "/security-advisories?state=published&per_page=5"
+	data, fetchedAt, err := fetchPayload(url, "json", func() ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "application/vnd.github+json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+		resp, err := feedClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("advisories returned status: %d", resp.StatusCode)
+		}
+		return io.ReadAll(io.LimitReader(resp.Body, maxArticleBytes))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var advisories []githubAdvisory
+	if err := json.Unmarshal(data, &advisories); err != nil {
+		return nil, fmt.Errorf("advisories decode failed: %v", err)
+	}
+
+	project := repo[strings.Index(repo, "/")+1:]
+	var items []NewsItem
+	for _, adv := range advisories {
+		id := adv.CVEID
+		if id == "" {
+			id = adv.GHSAID
+		}
+		title := collapseSpaces(fmt.Sprintf("%s security advisory %s (%s): %s", project, id, adv.Severity, adv.Summary))
+
+		ts := adv.PublishedAt
+		if ts.IsZero() {
+			ts = fetchedAt
+		}
+		items = append(items, NewsItem{
+			ID:          CanonicalID(adv.HTMLURL, s.name, title),
+			Title:       title,
+			Source:      s.name,
+			Timestamp:   ts,
+			Link:        adv.HTMLURL,
+			Description: truncate(collapseSpaces(adv.Description), 1000),
+			AssetHint:   s.repos[repo],
+			Category:    CategorySecurity,
+		})
+	}
+	return items, nil
+}
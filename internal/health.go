@@ -0,0 +1,119 @@
+package internal
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Health status values reported by /api/sources
+const (
+	StatusPending  = "PENDING"  // Not fetched yet
+	StatusOK       = "OK"       // Last fetch succeeded
+	StatusDegraded = "DEGRADED" // A few failures in a row
+	StatusDown     = "DOWN"     // Failing for a while
+)
+
+// downAfterFailures marks a source DOWN after this many consecutive errors
+const downAfterFailures = 5
+
+// SourceHealth tracks how a single source has been behaving
+type SourceHealth struct {
+	Name                string    `json:"Name"`
+	Kind                string    `json:"Kind"`
+	LastSuccess         time.Time `json:"LastSuccess"`
+	LastError           string    `json:"LastError"`
+	LastErrorAt         time.Time `json:"LastErrorAt"`
+	ConsecutiveFailures int       `json:"ConsecutiveFailures"`
+	LastItemCount       int       `json:"LastItemCount"`
+	LastLatencyMs       int64     `json:"LastLatencyMs"`
+	TotalFetches        int       `json:"TotalFetches"`
+	TotalFailures       int       `json:"TotalFailures"`
+}
+
+// SourceStatus is the API view: health plus config and a derived status
+type SourceStatus struct {
+	SourceHealth
+	Interval string `json:"Interval"`
+	Status   string `json:"Status"`
+}
+
+var (
+	healthMu sync.Mutex
+	health   = make(map[string]*SourceHealth)
+)
+
+// LoadSourceHealth restores persisted health state (call after InitDB)
+func LoadSourceHealth() {
+	healthMu.Lock()
+	defer healthMu.Unlock()
+	for _, h := range GetSavedSourceHealth() {
+		h := h
+		health[h.Name] = &h
+	}
+}
+
+// RecordFetch updates (and persists) the health of a source after a fetch
+func RecordFetch(name, kind string, items int, latency time.Duration, err error) SourceHealth {
+	healthMu.Lock()
+	h, ok := health[name]
+	if !ok {
+		h = &SourceHealth{Name: name}
+		health[name] = h
+	}
+
+	h.Kind = kind
+	h.TotalFetches++
+	h.LastLatencyMs = latency.Milliseconds()
+	if err != nil {
+		h.ConsecutiveFailures++
+		h.TotalFailures++
+		h.LastError = err.Error()
+		h.LastErrorAt = time.Now()
+	} else {
+		h.ConsecutiveFailures = 0
+		h.LastSuccess = time.Now()
+		h.LastItemCount = items
+	}
+	snapshot := *h
+	healthMu.Unlock()
+
+	SaveSourceHealth(snapshot)
+	return snapshot
+}
+
+// GetSourceStatuses reports every registered source, sorted by name
+func GetSourceStatuses() []SourceStatus {
+	healthMu.Lock()
+	defer healthMu.Unlock()
+
+	var list []SourceStatus
+	for _, cfg := range SourceConfigs() {
+		status := SourceStatus{
+			SourceHealth: SourceHealth{Name: cfg.Name, Kind: cfg.Type},
+			Interval:     cfg.PollInterval().String(),
+			Status:       StatusPending,
+		}
+		if h, ok := health[cfg.Name]; ok {
+			status.SourceHealth = *h
+			status.Status = statusOf(*h)
+		}
+		list = append(list, status)
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+func statusOf(h SourceHealth) string {
+	switch {
+	case h.TotalFetches == 0:
+		return StatusPending
+	case h.ConsecutiveFailures >= downAfterFailures:
+		return StatusDown
+	case h.ConsecutiveFailures > 0:
+		return StatusDegraded
+	default:
+		return StatusOK
+	}
+}
@@ -0,0 +1,81 @@
+package internal
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// trackingParams are query parameters that never change which article a URL points at
+var trackingParams = map[string]bool{
+	"fbclid": true, "gclid": true, "mc_cid": true, "mc_eid": true,
+	"ref": true, "ref_src": true, "cmpid": true, "ocid": true, "guccounter": true,
+}
+
+// CanonicalURL normalizes a link so the same article always maps to one string:
+// https scheme, lowercase host without "www.", no default port, no fragment,
+// no utm_* / tracking params, sorted query, no trailing slash.
+func CanonicalURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return raw
+	}
+
+	if u.Scheme == "http" || u.Scheme == "https" {
+		u.Scheme = "https"
+	}
+	host := strings.ToLower(u.Hostname())
+	host = strings.TrimPrefix(host, "www.")
+	if port := u.Port(); port != "" && port != "80" && port != "443" {
+		host += ":" + port
+	}
+	u.Host = host
+	u.User = nil
+	u.Fragment, u.RawFragment = "", ""
+
+	query := u.Query()
+	for key := range query {
+		if strings.HasPrefix(strings.ToLower(key), "utm_") || trackingParams[strings.ToLower(key)] {
+			query.Del(key)
+		}
+	}
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, url.QueryEscape(key)+"="+url.QueryEscape(v))
+		}
+	}
+	u.RawQuery = strings.Join(parts, "&")
+
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = ""
+	return u.String()
+}
+
+// CanonicalID derives a stable item ID that doesn't depend on the feed's GUID:
+// a hash of the canonical link, or of source + normalized title when there is no link.
+func CanonicalID(link, source, title string) string {
+	if link != "" {
+		return "u-" + shortHash(CanonicalURL(link))
+	}
+	return "h-" + shortHash(strings.ToLower(strings.TrimSpace(source))+"|"+normalizeTitle(title))
+}
+
+func normalizeTitle(title string) string {
+	return strings.Join(strings.Fields(strings.ToLower(title)), " ")
+}
+
+func shortHash(s string) string {
+	sum := sha1.Sum([]byte(s))
+	return hex.EncodeToString(sum[:8])
+}
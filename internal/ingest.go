@@ -0,0 +1,121 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxIngestBatch caps how many items one push request may carry
+const maxIngestBatch = 100
+
+// IngestItem is one pushed news item, as sent to POST /api/ingest
+type IngestItem struct {
+	Title       string  `json:"title"`
+	Source      string  `json:"source"`
+	Link        string  `json:"link,omitempty"`
+	Timestamp   string  `json:"timestamp,omitempty"` // RFC 3339, default now
+	Description string  `json:"description,omitempty"`
+	Body        string  `json:"body,omitempty"`
+	Trust       float64 `json:"trust,omitempty"` // 0..1, 0 = default
+}
+
+// IngestError explains why one item of a batch was rejected
+type IngestError struct {
+	Index int    `json:"index"`
+	Error string `json:"error"`
+}
+
+// ParseIngest decodes a single item or a JSON array of items and validates
+// each one. Valid items come back with canonical IDs; invalid ones are
+// reported by their index in the batch.
+func ParseIngest(r io.Reader) ([]NewsItem, []IngestError, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	data = bytes.TrimSpace(data)
+
+	var batch []IngestItem
+	if len(data) > 0 && data[0] == '[' {
+		err = json.Unmarshal(data, &batch)
+	} else {
+		var single IngestItem
+		err = json.Unmarshal(data, &single)
+		batch = []IngestItem{single}
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+	if len(batch) == 0 {
+		return nil, nil, fmt.Errorf("no items")
+	}
+	if len(batch) > maxIngestBatch {
+		return nil, nil, fmt.Errorf("too many items: %d (max %d)", len(batch), maxIngestBatch)
+	}
+
+	var items []NewsItem
+	var errs []IngestError
+	for i, in := range batch {
+		item, err := in.toNewsItem()
+		if err != nil {
+			errs = append(errs, IngestError{Index: i, Error: err.Error()})
+			continue
+		}
+		items = append(items, item)
+	}
+	return items, errs, nil
+}
+
+func (in IngestItem) toNewsItem() (NewsItem, error) {
+	title := collapseSpaces(in.Title)
+	source := strings.TrimSpace(in.Source)
+	if title == "" {
+		return NewsItem{}, fmt.Errorf("title is required")
+	}
+	if source == "" {
+		return NewsItem{}, fmt.Errorf("source is required")
+	}
+	if len(title) > 500 {
+		return NewsItem{}, fmt.Errorf("title too long")
+	}
+	if in.Trust < 0 || in.Trust > 1 {
+		return NewsItem{}, fmt.Errorf("trust must be between 0 and 1")
+	}
+
+	link := strings.TrimSpace(in.Link)
+	if link != "" {
+		u, err := url.Parse(link)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return NewsItem{}, fmt.Errorf("link must be an absolute http(s) URL")
+		}
+	}
+
+	ts := time.Now()
+	if in.Timestamp != "" {
+		parsed, err := time.Parse(time.RFC3339, strings.TrimSpace(in.Timestamp))
+		if err != nil {
+			return NewsItem{}, fmt.Errorf("timestamp must be RFC 3339")
+		}
+		// Clock skew is fine, scheduled "news" is not
+		if parsed.After(ts.Add(time.Hour)) {
+			return NewsItem{}, fmt.Errorf("timestamp is in the future")
+		}
+		ts = parsed
+	}
+
+	return NewsItem{
+		ID:          CanonicalID(link, source, title),
+		Title:       title,
+		Source:      source,
+		Timestamp:   ts,
+		Trust:       in.Trust,
+		Link:        link,
+		Description: truncate(cleanText(in.Description), 1000),
+		Body:        truncate(cleanText(in.Body), maxBodyChars),
+	}, nil
+}
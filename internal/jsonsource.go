@@ -0,0 +1,262 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// JSONSpec maps a JSON API response onto NewsItems.
+// Items is a JSONPath-style selector ("$.data.catalogs[*].articles");
+// the field mappings are Go templates run against each item, e.g.
+// "binance-{{.code}}" or "{{.data.title}}".
+type JSONSpec struct {
+	Items      string `json:"items"`
+	ID         string `json:"id,omitempty"` // Stable upstream key; default: CanonicalID of link / title
+	Title      string `json:"title"`
+	Link       string `json:"link,omitempty"`
+	Timestamp  string `json:"timestamp,omitempty"`
+	TimeFormat string `json:"time_format,omitempty"` // unix, unix_ms or a Go layout (default: auto)
+	Limit      int    `json:"limit,omitempty"`       // Default 10
+}
+
+// jsonSource polls a JSON endpoint described entirely by config
+type jsonSource struct {
+	name    string
+	url     string
+	method  string
+	headers map[string]string
+	body    string
+	spec    JSONSpec
+
+	id, title, link, timestamp *template.Template
+}
+
+func newJSONSource(cfg SourceConfig) (Source, error) {
+	if cfg.URL == "" || cfg.JSON == nil || cfg.JSON.Items == "" || cfg.JSON.Title == "" {
+		return nil, fmt.Errorf("json source %s needs a url, json.items and json.title", cfg.Name)
+	}
+
+	s := &jsonSource{
+		name:    cfg.Name,
+		url:     cfg.URL,
+		method:  strings.ToUpper(cfg.Method),
+		headers: cfg.Headers,
+		body:    cfg.Body,
+		spec:    *cfg.JSON,
+	}
+	if s.method == "" {
+		s.method = "GET"
+	}
+
+	var err error
+	for _, field := range []struct {
+		dst  **template.Template
+		name string
+		text string
+	}{
+		{&s.id, "id", s.spec.ID},
+		{&s.title, "title", s.spec.Title},
+		{&s.link, "link", s.spec.Link},
+		{&s.timestamp, "timestamp", s.spec.Timestamp},
+	} {
+		if field.text == "" {
+			continue
+		}
+		if *field.dst, err = template.New(field.name).Parse(field.text); err != nil {
+			return nil, fmt.Errorf("json source %s: bad %s template: %v", cfg.Name, field.name, err)
+		}
+	}
+	return s, nil
+}
+
+func (s *jsonSource) Name() string { return s.name }
+func (s *jsonSource) Kind() string { return KindJSON }
+
+func (s *jsonSource) Fetch(ctx context.Context) ([]NewsItem, error) {
+	data, fetchedAt, err := fetchPayload(s.method+" "+s.url+" "+s.body, "json", func() ([]byte, error) {
+		var body io.Reader
+		if s.body != "" {
+			body = strings.NewReader(s.body)
+		}
+		req, err := http.NewRequestWithContext(ctx, s.method, s.url, body)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("User-Agent", "Mozilla/5.0")
+		if s.body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		for k, v := range s.headers {
+			req.Header.Set(k, v)
+		}
+
+		resp, err := feedClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("json api returned status: %d", resp.StatusCode)
+		}
+		return io.ReadAll(io.LimitReader(resp.Body, maxArticleBytes))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.parse(data, fetchedAt)
+}
+
+// parse maps the payload's entries to items; undated entries get fetchedAt
+func (s *jsonSource) parse(data []byte, fetchedAt time.Time) ([]NewsItem, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber() // Keep large IDs / millisecond timestamps exact
+	var root interface{}
+	if err := dec.Decode(&root); err != nil {
+		return nil, fmt.Errorf("json decode failed: %v", err)
+	}
+
+	entries, err := jsonPath(root, s.spec.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := s.spec.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+
+	var items []NewsItem
+	for _, entry := range entries {
+		if len(items) >= limit {
+			break
+		}
+
+		title := collapseSpaces(render(s.title, entry))
+		if title == "" {
+			continue
+		}
+		item := NewsItem{
+			ID:        render(s.id, entry),
+			Title:     title,
+			Source:    s.name,
+			Link:      render(s.link, entry),
+			Timestamp: fetchedAt,
+		}
+		if ts := render(s.timestamp, entry); strings.TrimSpace(ts) != "" {
+			item.Timestamp = parseJSONTime(ts, s.spec.TimeFormat)
+		}
+		if item.ID == "" {
+			item.ID = CanonicalID(item.Link, s.name, title)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// render executes a field template; missing fields render as ""
+func render(t *template.Template, data interface{}) string {
+	if t == nil {
+		return ""
+	}
+	var sb strings.Builder
+	if err := t.Execute(&sb, data); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(strings.ReplaceAll(sb.String(), "<no value>", ""))
+}
+
+// jsonPath resolves a small JSONPath subset: $.a.b[0].c[*].d
+// A path ending on an array yields its elements.
+func jsonPath(root interface{}, path string) ([]interface{}, error) {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+
+	current := []interface{}{root}
+	if path != "" {
+		for _, segment := range strings.Split(path, ".") {
+			name, index := segment, ""
+			if i := strings.Index(segment, "["); i >= 0 && strings.HasSuffix(segment, "]") {
+				name, index = segment[:i], segment[i+1:len(segment)-1]
+			}
+
+			var next []interface{}
+			for _, node := range current {
+				if name != "" {
+					obj, ok := node.(map[string]interface{})
+					if !ok {
+						continue
+					}
+					if node, ok = obj[name]; !ok {
+						continue
+					}
+				}
+				if index == "" {
+					next = append(next, node)
+					continue
+				}
+
+				arr, ok := node.([]interface{})
+				if !ok {
+					continue
+				}
+				if index == "*" {
+					next = append(next, arr...)
+					continue
+				}
+				i, err := strconv.Atoi(index)
+				if err != nil {
+					return nil, fmt.Errorf("bad index %q in path %q", index, path)
+				}
+				if i >= 0 && i < len(arr) {
+					next = append(next, arr[i])
+				}
+			}
+			current = next
+		}
+	}
+
+	// "$.data.list" should mean the list's items, not the list itself
+	if len(current) == 1 {
+		if arr, ok := current[0].([]interface{}); ok {
+			return arr, nil
+		}
+	}
+	return current, nil
+}
+
+// parseJSONTime understands epoch seconds / millis and the usual date layouts
+func parseJSONTime(value, format string) time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Now()
+	}
+
+	switch format {
+	case "unix", "unix_ms":
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return time.Now()
+		}
+		if format == "unix_ms" {
+			return time.UnixMilli(int64(n))
+		}
+		return time.Unix(int64(n), 0)
+	case "":
+		// Auto: bare numbers are epochs, millis if they're too big for seconds
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			if n > 1e12 {
+				return time.UnixMilli(int64(n))
+			}
+			return time.Unix(int64(n), 0)
+		}
+	}
+	return parseScrapedDate(value, format)
+}
@@ -0,0 +1,119 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// languageNames are used in the translation prompt
+var languageNames = map[string]string{
+	"ar": "Arabic",
+	"zh": "Chinese",
+	"ja": "Japanese",
+	"ko": "Korean",
+	"ru": "Russian",
+}
+
+// DetectLanguage guesses a headline's language from its script: "ar", "zh",
+// "ja", "ko", "ru", or "en" for Latin text. Good enough to route non-English
+// headlines to translation; it can't tell Latin-script languages apart.
+func DetectLanguage(text string) string {
+	counts := make(map[string]int)
+	letters := 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.Is(unicode.Arabic, r):
+			counts["ar"]++
+		case unicode.Is(unicode.Hangul, r):
+			counts["ko"]++
+		case unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r):
+			counts["ja"]++
+		case unicode.Is(unicode.Han, r):
+			counts["zh"]++
+		case unicode.Is(unicode.Cyrillic, r):
+			counts["ru"]++
+		}
+	}
+	if letters == 0 {
+		return "en"
+	}
+
+	// Japanese mixes kanji (Han) with kana: any kana means Japanese
+	if counts["ja"] > 0 {
+		counts["ja"] += counts["zh"]
+		counts["zh"] = 0
+	}
+
+	best, bestCount := "en", 0
+	for lang, n := range counts {
+		if n > bestCount {
+			best, bestCount = lang, n
+		}
+	}
+	// Tickers and brand names are Latin in every language, so a
+	// non-Latin script only needs a fair share of the letters
+	if bestCount*4 < letters {
+		return "en"
+	}
+	return best
+}
+
+// translationEnabled reports whether TRANSLATE_TITLES asks for LLM translation
+func translationEnabled() bool {
+	v := strings.ToLower(os.Getenv("TRANSLATE_TITLES"))
+	return v == "1" || v == "true" || v == "yes"
+}
+
+// PrepareLanguage is the pipeline stage before rule analysis: it records the
+// headline language and, when TRANSLATE_TITLES is set, adds an English
+// translation in TitleEN. Title itself is left as published for display.
+// Translation gives up when ctx ends; the item then goes on untranslated.
+func PrepareLanguage(ctx context.Context, item *NewsItem) {
+	item.Language = DetectLanguage(item.Title)
+	if item.Language == "en" || item.TitleEN != "" || !translationEnabled() || ctx.Err() != nil {
+		return
+	}
+
+	translated, err := TranslateTitle(ctx, item.Title, item.Language)
+	if err != nil {
+		log.Printf("⚠️  Could not translate %q: %v", item.Title, err)
+		return
+	}
+	item.TitleEN = translated
+}
+
+// TranslateTitle asks the configured LLM for an English version of a headline
+func TranslateTitle(ctx context.Context, title, lang string) (string, error) {
+	name := languageNames[lang]
+	if name == "" {
+		name = lang
+	}
+	prompt := fmt.Sprintf(`Translate this %s crypto news headline to English.
+Keep coin names, tickers and numbers unchanged. Reply with the translation only.
+
+%s`, name, title)
+
+	raw, err := callLLM(ctx, prompt, false)
+	if err != nil {
+		return "", err
+	}
+
+	// Models like to wrap the answer in quotes or add a second line of notes
+	translated := strings.TrimSpace(raw)
+	if i := strings.Index(translated, "\n"); i >= 0 {
+		translated = translated[:i]
+	}
+	translated = strings.Trim(translated, "\"'“”「」 ")
+	if translated == "" {
+		return "", fmt.Errorf("empty translation")
+	}
+	return truncate(translated, 300), nil
+}
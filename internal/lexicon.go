@@ -0,0 +1,266 @@
+package internal
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+)
+
+// defaultLexicon is used when no lexicon file exists
+//
+//go:embed lexicon.json
+var defaultLexicon []byte
+
+// Lexicon is everything AnalyzeNews knows about words: which ones make a
+// headline market-wide, name an asset, move sentiment or mark an event.
+// Terms are matched case-insensitively.
+type Lexicon struct {
+	Version       int     `json:"version"`
+	DefaultImpact float64 `json:"default_impact"`
+	MarketImpact  float64 `json:"market_impact"`
+	SummaryWeight float64 `json:"summary_weight"` // Keyword weight in the description relative to the headline
+
+	MarketKeywords []string        `json:"market_keywords"`
+	Assets         []AssetAliases  `json:"assets"` // Ranked by relevance (see tagAssets); ties keep this order, the AssetHint stays first
+	Keywords       []KeywordWeight `json:"keywords"`
+	Events         []EventRule     `json:"events"`
+	PriceNoise     PriceNoiseRule  `json:"price_noise"`
+	SecurityTerms  []string        `json:"security_terms"` // Mark a protocol release as a security fix
+	Modifiers      Modifiers       `json:"modifiers"`      // Version 2: negation / hedging around keywords
+}
+
+// Modifiers are the words that change what a nearby keyword means: negators
+// ("not", "fails to") flip it, hedges ("may", "rumor") and question headlines
+// weaken it. Window is how many words may sit between modifier and keyword.
+type Modifiers struct {
+	Window         int      `json:"window"`
+	Negators       []string `json:"negators"`
+	Hedges         []string `json:"hedges"`
+	NegationFactor float64  `json:"negation_factor"` // Sentiment multiplier for a negated keyword (negative flips it)
+	HedgeFactor    float64  `json:"hedge_factor"`    // Sentiment multiplier for a hedged keyword
+	QuestionFactor float64  `json:"question_factor"` // Headline sentiment multiplier when the headline is a question
+	ImpactFactor   float64  `json:"impact_factor"`   // Impact multiplier for hedged or question headlines
+}
+
+// AssetAliases maps the words that name an asset to its symbol
+type AssetAliases struct {
+	Symbol  string   `json:"symbol"`
+	Aliases []string `json:"aliases"`
+}
+
+// KeywordWeight is one sentiment word; Impact, if set, is a floor for the item's impact
+type KeywordWeight struct {
+	Term      string  `json:"term"`
+	Sentiment float64 `json:"sentiment"`
+	Impact    float64 `json:"impact,omitempty"`
+}
+
+// EventRule raises impact (and sets the category) when a headline reports an event
+type EventRule struct {
+	Type   string   `json:"type"`
+	Terms  []string `json:"terms"`
+	Impact float64  `json:"impact"`
+}
+
+// PriceNoiseRule demotes headlines that only describe price moves
+type PriceNoiseRule struct {
+	Terms  []string `json:"terms"`
+	Unless []string `json:"unless"` // Any of these means there is a real event behind the move
+	Impact float64  `json:"impact"`
+}
+
+var (
+	lexiconMu sync.RWMutex
+	lexicon   *Lexicon
+)
+
+// LexiconPath returns LEXICON_PATH or the default ./lexicon.json
+func LexiconPath() string {
+	if path := os.Getenv("LEXICON_PATH"); path != "" {
+		return path
+	}
+	return "./lexicon.json"
+}
+
+// ParseLexicon decodes and validates a lexicon, lowercasing its terms
+func ParseLexicon(data []byte) (*Lexicon, error) {
+	var lex Lexicon
+	if err := json.Unmarshal(data, &lex); err != nil {
+		return nil, fmt.Errorf("invalid lexicon: %v", err)
+	}
+	if err := lex.validate(); err != nil {
+		return nil, err
+	}
+	return &lex, nil
+}
+
+func (l *Lexicon) validate() error {
+	if l.Version < 1 {
+		return fmt.Errorf("lexicon: version must be >= 1")
+	}
+	for name, v := range map[string]float64{
+		"default_impact": l.DefaultImpact, "market_impact": l.MarketImpact,
+		"summary_weight": l.SummaryWeight, "price_noise.impact": l.PriceNoise.Impact,
+	} {
+		if v < 0 || v > 1 {
+			return fmt.Errorf("lexicon: %s must be between 0 and 1", name)
+		}
+	}
+
+	var err error
+	if l.MarketKeywords, err = normalizeTerms("market_keywords", l.MarketKeywords); err != nil {
+		return err
+	}
+	if l.PriceNoise.Terms, err = normalizeTerms("price_noise.terms", l.PriceNoise.Terms); err != nil {
+		return err
+	}
+	if l.PriceNoise.Unless, err = normalizeTerms("price_noise.unless", l.PriceNoise.Unless); err != nil {
+		return err
+	}
+	if l.SecurityTerms, err = normalizeTerms("security_terms", l.SecurityTerms); err != nil {
+		return err
+	}
+	if err := l.Modifiers.validate(); err != nil {
+		return err
+	}
+
+	if len(l.Assets) == 0 {
+		return fmt.Errorf("lexicon: no assets")
+	}
+	symbols := make(map[string]bool)
+	for i := range l.Assets {
+		a := &l.Assets[i]
+		a.Symbol = strings.ToUpper(strings.TrimSpace(a.Symbol))
+		if a.Symbol == "" || symbols[a.Symbol] {
+			return fmt.Errorf("lexicon: asset %d has an empty or duplicate symbol %q", i, a.Symbol)
+		}
+		symbols[a.Symbol] = true
+		if a.Aliases, err = normalizeTerms("assets."+a.Symbol, a.Aliases); err != nil {
+			return err
+		}
+		if len(a.Aliases) == 0 {
+			return fmt.Errorf("lexicon: asset %s has no aliases", a.Symbol)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for i := range l.Keywords {
+		kw := &l.Keywords[i]
+		kw.Term = strings.ToLower(strings.TrimSpace(kw.Term))
+		if kw.Term == "" || seen[kw.Term] {
+			return fmt.Errorf("lexicon: keyword %d is empty or duplicate (%q)", i, kw.Term)
+		}
+		seen[kw.Term] = true
+		if kw.Sentiment < -1 || kw.Sentiment > 1 {
+			return fmt.Errorf("lexicon: keyword %q sentiment must be between -1 and 1", kw.Term)
+		}
+		if kw.Impact < 0 || kw.Impact > 1 {
+			return fmt.Errorf("lexicon: keyword %q impact must be between 0 and 1", kw.Term)
+		}
+	}
+
+	for i := range l.Events {
+		ev := &l.Events[i]
+		ev.Type = strings.ToUpper(strings.TrimSpace(ev.Type))
+		if ev.Type == "" {
+			return fmt.Errorf("lexicon: event %d has no type", i)
+		}
+		if ev.Impact < 0 || ev.Impact > 1 {
+			return fmt.Errorf("lexicon: event %s impact must be between 0 and 1", ev.Type)
+		}
+		if ev.Terms, err = normalizeTerms("events."+ev.Type, ev.Terms); err != nil {
+			return err
+		}
+		if len(ev.Terms) == 0 {
+			return fmt.Errorf("lexicon: event %s has no terms", ev.Type)
+		}
+	}
+	return nil
+}
+
+func (m *Modifiers) validate() error {
+	if m.Window < 0 || m.Window > 10 {
+		return fmt.Errorf("lexicon: modifiers.window must be between 0 and 10")
+	}
+	if m.NegationFactor < -1 || m.NegationFactor > 1 {
+		return fmt.Errorf("lexicon: modifiers.negation_factor must be between -1 and 1")
+	}
+	for name, v := range map[string]float64{
+		"hedge_factor": m.HedgeFactor, "question_factor": m.QuestionFactor, "impact_factor": m.ImpactFactor,
+	} {
+		if v < 0 || v > 1 {
+			return fmt.Errorf("lexicon: modifiers.%s must be between 0 and 1", name)
+		}
+	}
+
+	var err error
+	if m.Negators, err = normalizeTerms("modifiers.negators", m.Negators); err != nil {
+		return err
+	}
+	m.Hedges, err = normalizeTerms("modifiers.hedges", m.Hedges)
+	return err
+}
+
+// normalizeTerms lowercases and trims a term list, rejecting blanks
+func normalizeTerms(field string, terms []string) ([]string, error) {
+	out := make([]string, 0, len(terms))
+	for _, t := range terms {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" {
+			return nil, fmt.Errorf("lexicon: empty term in %s", field)
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+// ReloadLexicon loads the lexicon file (or the built-in one when the file
+// doesn't exist) and swaps it in. On error the current lexicon stays.
+func ReloadLexicon(path string) error {
+	data, err := os.ReadFile(path)
+	source := path
+	if os.IsNotExist(err) {
+		data, source, err = defaultLexicon, "built-in", nil
+	}
+	if err != nil {
+		return err
+	}
+
+	lex, err := ParseLexicon(data)
+	if err != nil {
+		return fmt.Errorf("%s: %v", source, err)
+	}
+
+	lexiconMu.Lock()
+	lexicon = lex
+	lexiconMu.Unlock()
+	log.Printf("📖 Lexicon v%d loaded from %s (%d keywords, %d assets, %d events)",
+		lex.Version, source, len(lex.Keywords), len(lex.Assets), len(lex.Events))
+	return nil
+}
+
+// CurrentLexicon returns the active lexicon, loading the built-in one on first use
+func CurrentLexicon() *Lexicon {
+	lexiconMu.RLock()
+	lex := lexicon
+	lexiconMu.RUnlock()
+	if lex != nil {
+		return lex
+	}
+
+	lex, err := ParseLexicon(defaultLexicon)
+	if err != nil {
+		log.Fatal("Built-in lexicon is invalid: ", err)
+	}
+	lexiconMu.Lock()
+	if lexicon == nil {
+		lexicon = lex
+	}
+	lex = lexicon
+	lexiconMu.Unlock()
+	return lex
+}
@@ -2,16 +2,48 @@ package internal
 
 import "time"
 
+// Item categories set by collectors that know what kind of event they report
+const (
+	CategoryRelease  = "RELEASE"  // Protocol / client release
+	CategorySecurity = "SECURITY" // Security release or advisory
+)
+
 // NewsItem defines a normalized news struct
 type NewsItem struct {
-	ID        string    `json:"ID"`
-	Title     string    `json:"Title"`
-	Source    string    `json:"Source"`
-	Scope     string    `json:"Scope"`
-	Asset     string    `json:"Asset"`
-	Impact    float64   `json:"Impact"`
-	Sentiment float64   `json:"Sentiment"`
-	Timestamp time.Time `json:"Timestamp"`
+	ID        string     `json:"ID"`
+	Title     string     `json:"Title"`
+	Source    string     `json:"Source"`
+	Scope     string     `json:"Scope"`
+	Asset     string     `json:"Asset"`            // Primary asset (highest relevance)
+	Assets    []AssetTag `json:"Assets,omitempty"` // Every asset mentioned, most relevant first
+	Impact    float64    `json:"Impact"`
+	Sentiment float64    `json:"Sentiment"`
+	Timestamp time.Time  `json:"Timestamp"`
+	Trust     float64    `json:"Trust,omitempty"` // From source config, 0 = default
+	Category  string     `json:"Category,omitempty"`
+	AssetHint string     `json:"AssetHint,omitempty"` // Asset known from the source (e.g. repo), wins over detection
+
+	// Language of the headline ("en", "ar", "zh", ...) and its English translation
+	Language string `json:"Language,omitempty"`
+	TitleEN  string `json:"TitleEN,omitempty"`
+
+	// Why the analyzer flipped or weakened sentiment (negation, hedging, question)
+	SentimentNote string `json:"SentimentNote,omitempty"`
+
+	// Article content (RSS description / content:encoded / extracted page)
+	Link        string `json:"Link,omitempty"`
+	Description string `json:"Description,omitempty"`
+	Body        string `json:"Body,omitempty"`
+
+	// Community engagement (reddit / forum sources)
+	Social    bool   `json:"Social,omitempty"`
+	Community string `json:"Community,omitempty"` // e.g. "r/Bitcoin"
+	Upvotes   int    `json:"Upvotes,omitempty"`
+	Comments  int    `json:"Comments,omitempty"`
+
+	// Cross-source clustering
+	StoryID  string `json:"StoryID"`
+	Coverage int    `json:"Coverage"` // Number of sources reporting the story
 
 	// Phase 3: Decision Support
 	TradingSignal string  `json:"TradingSignal"`
@@ -23,3 +55,18 @@ type NewsItem struct {
 	AIAdvice   string `json:"AIAdvice"`
 	CoinSymbol string `json:"CoinSymbol"`
 }
+
+// AssetTag is one asset an item mentions, with how central it is (0..1)
+type AssetTag struct {
+	Symbol    string  `json:"Symbol"`
+	Relevance float64 `json:"Relevance"`
+}
+
+// EnglishTitle is the headline used for analysis: the translation when
+// there is one, otherwise the title as published
+func (n NewsItem) EnglishTitle() string {
+	if n.TitleEN != "" {
+		return n.TitleEN
+	}
+	return n.Title
+}
@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// modifierSpan is where a negator or hedge sits, in token indexes [start, end)
+type modifierSpan struct {
+	start, end int
+	term       string
+}
+
+// modifierScan knows where the negators and hedges of one text are, so each
+// keyword match can be checked against its neighbours
+type modifierScan struct {
+	mods     *Modifiers
+	text     *TokenizedText
+	negators []modifierSpan
+	hedges   []modifierSpan
+}
+
+func scanModifiers(mods *Modifiers, text *TokenizedText) *modifierScan {
+	return &modifierScan{
+		mods:     mods,
+		text:     text,
+		negators: findSpans(text, mods.Negators),
+		hedges:   findSpans(text, mods.Hedges),
+	}
+}
+
+func findSpans(text *TokenizedText, terms []string) []modifierSpan {
+	var spans []modifierSpan
+	for _, term := range terms {
+		n := len(Tokenize(term).Tokens)
+		for _, i := range text.Find(term) {
+			spans = append(spans, modifierSpan{start: i, end: i + n, term: term})
+		}
+	}
+	return spans
+}
+
+// match looks term up and reports what modifies it. A negator counts when it
+// ends at most Window words before the keyword ("does not crash"); a hedge
+// counts on either side ("could rally", "rally rumored"). Neither reaches
+// across clause punctuation ("No, Bitcoin is not dead: it rallies"). If any
+// occurrence is unmodified, the keyword stands as is and negator / hedge are empty.
+func (s *modifierScan) match(term string) (found bool, negator, hedge string) {
+	at := s.text.Find(term)
+	if len(at) == 0 {
+		return false, "", ""
+	}
+	n := len(Tokenize(term).Tokens)
+
+	for k, i := range at {
+		neg, hed := "", ""
+		for _, sp := range s.negators {
+			if sp.end <= i && i-sp.end <= s.mods.Window && s.sameClause(sp.end-1, i) {
+				neg = sp.term
+			}
+		}
+		for _, sp := range s.hedges {
+			before := sp.end <= i && i-sp.end <= s.mods.Window && s.sameClause(sp.end-1, i)
+			after := sp.start >= i+n && sp.start-(i+n) <= s.mods.Window && s.sameClause(i+n-1, sp.start)
+			if before || after {
+				hed = sp.term
+			}
+		}
+		if neg == "" && hed == "" {
+			return true, "", ""
+		}
+		if k == 0 {
+			negator, hedge = neg, hed
+		}
+	}
+	return true, negator, hedge
+}
+
+// sameClause reports whether no clause punctuation (, ; : . ! ?) separates
+// token a from the later token b. Decimal points and thousands separators
+// ("2.5", "1,000") don't end a clause.
+func (s *modifierScan) sameClause(a, b int) bool {
+	raw := s.text.Raw
+	for p := s.text.Tokens[a].End; p < s.text.Tokens[b].Start; p++ {
+		if !strings.ContainsRune(",;:.!?", rune(raw[p])) {
+			continue
+		}
+		if p+1 < len(raw) && raw[p+1] >= '0' && raw[p+1] <= '9' {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// matchAny is match over a term list: the least-modified match wins
+// (plain, then hedged, then negated)
+func (s *modifierScan) matchAny(terms []string) (found bool, negator, hedge string) {
+	for _, term := range terms {
+		ok, neg, hed := s.match(term)
+		switch {
+		case !ok:
+			continue
+		case neg == "" && hed == "":
+			return true, "", ""
+		case !found || (negator != "" && neg == ""):
+			found, negator, hedge = true, neg, hed
+		}
+	}
+	return found, negator, hedge
+}
+
+// isQuestion reports whether a headline asks rather than tells ("Will BTC hit 100k?")
+func isQuestion(title string) bool {
+	return strings.HasSuffix(strings.TrimRight(title, " \"'”’»)"), "?")
+}
+
+// sentimentNotes collects why the analyzer weakened or flipped a headline
+type sentimentNotes []string
+
+func (n *sentimentNotes) add(format string, args ...interface{}) {
+	note := fmt.Sprintf(format, args...)
+	if !containsString(*n, note) {
+		*n = append(*n, note)
+	}
+}
+
+func (n sentimentNotes) String() string {
+	return strings.Join(n, "; ")
+}
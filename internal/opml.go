@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// OPML document layout (only the parts feed readers actually use)
+type opmlDoc struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    struct {
+		Title       string `xml:"title"`
+		DateCreated string `xml:"dateCreated,omitempty"`
+	} `xml:"head"`
+	Body struct {
+		Outlines []opmlOutline `xml:"outline"`
+	} `xml:"body"`
+}
+
+type opmlOutline struct {
+	Text     string        `xml:"text,attr"`
+	Title    string        `xml:"title,attr,omitempty"`
+	Type     string        `xml:"type,attr,omitempty"`
+	XMLURL   string        `xml:"xmlUrl,attr,omitempty"`
+	HTMLURL  string        `xml:"htmlUrl,attr,omitempty"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+// ParseOPML reads an OPML file into rss source configs.
+// Folders are flattened; outlines without an xmlUrl are skipped.
+func ParseOPML(r io.Reader) ([]SourceConfig, error) {
+	var doc opmlDoc
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("parse opml: %v", err)
+	}
+
+	var cfgs []SourceConfig
+	var walk func(outlines []opmlOutline)
+	walk = func(outlines []opmlOutline) {
+		for _, o := range outlines {
+			if o.XMLURL != "" {
+				name := strings.TrimSpace(o.Title)
+				if name == "" {
+					name = strings.TrimSpace(o.Text)
+				}
+				if name == "" {
+					name = o.XMLURL
+				}
+				cfgs = append(cfgs, SourceConfig{Name: name, Type: KindRSS, URL: strings.TrimSpace(o.XMLURL)})
+			}
+			walk(o.Outlines)
+		}
+	}
+	walk(doc.Body.Outlines)
+	return cfgs, nil
+}
+
+// WriteOPML exports the rss sources in cfgs as an OPML 2.0 document
+func WriteOPML(w io.Writer, cfgs []SourceConfig) error {
+	doc := opmlDoc{Version: "2.0"}
+	doc.Head.Title = "Crypto News Intelligence Sources"
+	doc.Head.DateCreated = time.Now().UTC().Format(time.RFC1123Z)
+
+	for _, cfg := range cfgs {
+		if cfg.Type != KindRSS || cfg.URL == "" {
+			continue // OPML readers only understand feeds
+		}
+		doc.Body.Outlines = append(doc.Body.Outlines, opmlOutline{
+			Text:   cfg.Name,
+			Title:  cfg.Name,
+			Type:   "rss",
+			XMLURL: cfg.URL,
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(doc)
+}
+
+// ImportOPML merges the feeds of an OPML file into the sources config at path.
+// Feeds whose URL is already configured are skipped; clashing names get a suffix.
+// Returns how many sources were added.
+func ImportOPML(path string, r io.Reader) (int, error) {
+	imported, err := ParseOPML(r)
+	if err != nil {
+		return 0, err
+	}
+
+	configWriteMu.Lock()
+	defer configWriteMu.Unlock()
+
+	current, err := ExportSourceConfigs(path)
+	if err != nil {
+		return 0, err
+	}
+
+	names := make(map[string]bool)
+	urls := make(map[string]bool)
+	for _, cfg := range current {
+		names[cfg.Name] = true
+		urls[cfg.URL] = true
+	}
+
+	added := 0
+	for _, cfg := range imported {
+		if urls[cfg.URL] {
+			continue
+		}
+		base := cfg.Name
+		for n := 2; names[cfg.Name]; n++ {
+			cfg.Name = fmt.Sprintf("%s (%d)", base, n)
+		}
+		names[cfg.Name], urls[cfg.URL] = true, true
+		current = append(current, cfg)
+		added++
+	}
+
+	if added == 0 {
+		return 0, nil
+	}
+	return added, saveSourcesConfig(path, current)
+}
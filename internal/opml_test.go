@@ -0,0 +1,39 @@
+package internal
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Concurrent imports (CLI-style and admin API) must not drop each other's feeds
+func TestImportOPMLConcurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sources.json")
+	if err := SaveSourcesConfig(path, []SourceConfig{{Name: "Existing", Type: KindRSS, URL: "https://example.com/existing.xml"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	const imports = 8
+	var wg sync.WaitGroup
+	for i := 0; i < imports; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			doc := fmt.Sprintf(`<opml version="2.0"><body><outline text="Feed %d" xmlUrl="https://example.com/%d.xml"/></body></opml>`, i, i)
+			if _, err := ImportOPML(path, strings.NewReader(doc)); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	cfgs, err := ExportSourceConfigs(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfgs) != imports+1 {
+		t.Errorf("got %d sources after %d imports, want %d", len(cfgs), imports, imports+1)
+	}
+}
@@ -0,0 +1,138 @@
+package internal
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const (
+	defaultFetchTimeout = 90 * time.Second
+	defaultMaxBackoff   = 10 * time.Minute
+	jitterFraction      = 0.1 // +/-10% of each delay
+)
+
+// FetchHandler receives the items of every successful fetch as soon as it
+// completes. ctx is cancelled when the scheduler stops or reloads, so slow
+// per-item work (enrichment, translation) should give up on it.
+type FetchHandler func(ctx context.Context, src Source, cfg SourceConfig, items []NewsItem)
+
+// Scheduler runs one polling goroutine per registered source
+type Scheduler struct {
+	handler FetchHandler
+
+	mu     sync.Mutex // Serializes Sync and Stop
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewScheduler creates a scheduler that feeds results to handler
+func NewScheduler(handler FetchHandler) *Scheduler {
+	return &Scheduler{handler: handler}
+}
+
+// Sync stops any running pollers and starts a fresh one for every
+// registered source. Call it again after the registry is reloaded.
+// The old pollers are fully stopped first, so a source is never polled
+// twice at once; handlers give up on the cancelled ctx, so the wait is short.
+func (s *Scheduler) Sync() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stopLocked()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	for _, src := range Sources() {
+		cfg, _ := SourceConfigFor(src.Name())
+		s.wg.Add(1)
+		go func(src Source, cfg SourceConfig) {
+			defer s.wg.Done()
+			s.run(ctx, src, cfg)
+		}(src, cfg)
+	}
+}
+
+// Stop halts all pollers and waits for them to return
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopLocked()
+}
+
+func (s *Scheduler) stopLocked() {
+	if s.cancel != nil {
+		s.cancel()
+		s.wg.Wait()
+		s.cancel = nil
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, src Source, cfg SourceConfig) {
+	interval := cfg.PollInterval()
+	maxBackoff := parseDurationOr(cfg.MaxBackoff, defaultMaxBackoff)
+	timeout := parseDurationOr(cfg.Timeout, defaultFetchTimeout)
+
+	for {
+		fetchCtx, cancel := context.WithTimeout(ctx, timeout)
+		started := time.Now()
+		items, err := src.Fetch(fetchCtx)
+		latency := time.Since(started)
+		cancel()
+
+		if ctx.Err() != nil {
+			return // Scheduler was stopped mid-fetch
+		}
+
+		h := RecordFetch(src.Name(), src.Kind(), len(items), latency, err)
+
+		delay := interval
+		if err != nil {
+			delay = backoffDelay(interval, h.ConsecutiveFailures, maxBackoff)
+			log.Printf("⚠️  Error fetching from %s (failure %d, retry in %s): %v", src.Name(), h.ConsecutiveFailures, delay.Round(time.Second), err)
+		} else if len(items) > 0 {
+			s.handler(ctx, src, cfg, items)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(withJitter(delay)):
+		}
+	}
+}
+
+// backoffDelay doubles the interval for every consecutive failure, capped at
+// max but never below interval: a failing source is not retried sooner than
+// a healthy one is polled
+func backoffDelay(interval time.Duration, failures int, max time.Duration) time.Duration {
+	delay := interval
+	for i := 0; i < failures && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	if delay < interval {
+		delay = interval
+	}
+	return delay
+}
+
+// withJitter spreads polls so sources do not fire in lockstep
+func withJitter(d time.Duration) time.Duration {
+	spread := int64(float64(d) * jitterFraction)
+	if spread <= 0 {
+		return d
+	}
+	return d + time.Duration(rand.Int63n(2*spread)-spread)
+}
+
+func parseDurationOr(s string, fallback time.Duration) time.Duration {
+	if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return d
+	}
+	return fallback
+}
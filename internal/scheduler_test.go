@@ -0,0 +1,26 @@
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffDelay(t *testing.T) {
+	tests := []struct {
+		interval time.Duration
+		failures int
+		max      time.Duration
+		want     time.Duration
+	}{
+		{time.Minute, 0, 30 * time.Minute, time.Minute},
+		{time.Minute, 3, 30 * time.Minute, 8 * time.Minute},
+		{time.Minute, 10, 30 * time.Minute, 30 * time.Minute},
+		// A slow source is never retried sooner than it is normally polled
+		{time.Hour, 1, 30 * time.Minute, time.Hour},
+	}
+	for _, tt := range tests {
+		if got := backoffDelay(tt.interval, tt.failures, tt.max); got != tt.want {
+			t.Errorf("backoffDelay(%v, %d, %v) = %v, want %v", tt.interval, tt.failures, tt.max, got, tt.want)
+		}
+	}
+}
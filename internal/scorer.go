@@ -1,6 +1,9 @@
 package internal
 
-import "strings"
+import (
+	"math"
+	"strings"
+)
 
 // CalculateScore calculates the final score based on impact, sentiment, and trust
 func CalculateScore(item NewsItem) float64 {
@@ -12,5 +15,42 @@ func CalculateScore(item NewsItem) float64 {
 		trustWeight = 1.0
 	}
 
-	return item.Impact * item.Sentiment * trustWeight
+	// Community posts are noisier than editorial news
+	if item.Social {
+		trustWeight = 0.4
+	}
+
+	// Explicit trust from the sources config wins
+	if item.Trust > 0 {
+		trustWeight = item.Trust
+	}
+
+	score := item.Impact * item.Sentiment * trustWeight * coverageBoost(item.Coverage)
+	if item.Social {
+		score *= engagementFactor(item.Upvotes, item.Comments)
+	}
+	return score
+}
+
+// engagementFactor scales social items by traction: 0.5 for an unnoticed post,
+// up to 1.0 around a thousand upvotes (comments count double)
+func engagementFactor(upvotes, comments int) float64 {
+	engagement := float64(upvotes + 2*comments)
+	if engagement < 0 {
+		engagement = 0
+	}
+	return 0.5 + 0.5*math.Min(1, math.Log10(1+engagement)/3)
+}
+
+// coverageBoost raises confidence when several sources report the same story
+// (+15% per extra source, capped at +50%)
+func coverageBoost(coverage int) float64 {
+	if coverage <= 1 {
+		return 1.0
+	}
+	boost := 1.0 + 0.15*float64(coverage-1)
+	if boost > 1.5 {
+		boost = 1.5
+	}
+	return boost
 }
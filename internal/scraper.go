@@ -0,0 +1,287 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/chromedp/chromedp"
+)
+
+// ScrapeSpec tells the generic scrapers where the entries live on a page.
+// Either the CSS selectors or (headless only) a JS Script must be set.
+type ScrapeSpec struct {
+	Items      string `json:"items,omitempty"`       // CSS selector matching each entry
+	Title      string `json:"title,omitempty"`       // Selector inside the entry ("" = entry text)
+	Link       string `json:"link,omitempty"`        // Selector of the <a> inside the entry ("" = entry itself)
+	Date       string `json:"date,omitempty"`        // Selector of the date inside the entry (datetime attr or text)
+	DateLayout string `json:"date_layout,omitempty"` // Go layout for Date, default tries common formats
+
+	Script  string `json:"script,omitempty"`   // JS expression returning [{title, link, date}]
+	WaitFor string `json:"wait_for,omitempty"` // Selector to wait for before extracting (headless)
+	Settle  string `json:"settle,omitempty"`   // Extra delay after WaitFor, e.g. "3s" (headless)
+
+	MinTitleLength int `json:"min_title_length,omitempty"`
+	Limit          int `json:"limit,omitempty"` // Default 10
+}
+
+// scrapedEntry is one raw result from a page, before it becomes a NewsItem
+type scrapedEntry struct {
+	Title string `json:"title"`
+	Link  string `json:"link"`
+	Date  string `json:"date"`
+}
+
+// scrapeSource is the config-driven "html" and "headless" source
+type scrapeSource struct {
+	name     string
+	kind     string
+	url      string
+	spec     ScrapeSpec
+	headless bool
+}
+
+func newHTMLSource(cfg SourceConfig) (Source, error) {
+	if cfg.URL == "" || cfg.Scrape == nil || cfg.Scrape.Items == "" {
+		return nil, fmt.Errorf("html source %s needs a url and scrape.items", cfg.Name)
+	}
+	return &scrapeSource{name: cfg.Name, kind: KindHTML, url: cfg.URL, spec: *cfg.Scrape}, nil
+}
+
+func (s *scrapeSource) Name() string { return s.name }
+func (s *scrapeSource) Kind() string { return s.kind }
+
+func (s *scrapeSource) Fetch(ctx context.Context) ([]NewsItem, error) {
+	var entries []scrapedEntry
+	var err error
+	if s.headless {
+		entries, err = ScrapeHeadless(ctx, s.url, s.spec)
+	} else {
+		entries, err = ScrapeHTML(ctx, s.url, s.spec)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return entriesToItems(entries, s.name, s.url, s.spec), nil
+}
+
+// ScrapeHTML downloads a static page and extracts entries with CSS selectors
+func ScrapeHTML(ctx context.Context, pageURL string, spec ScrapeSpec) ([]scrapedEntry, error) {
+	data, fetchedAt, err := fetchPayload(pageURL, "html", func() ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+
+		resp, err := articleClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("page returned status: %d", resp.StatusCode)
+		}
+		return io.ReadAll(io.LimitReader(resp.Body, maxArticleBytes))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return stampReplayDates(selectEntries(doc, spec), fetchedAt), nil
+}
+
+// ScrapeHeadless renders a page in the shared browser, then extracts entries
+// with spec.Script if set, otherwise with the CSS selectors.
+func ScrapeHeadless(ctx context.Context, pageURL string, spec ScrapeSpec) ([]scrapedEntry, error) {
+	ext := "html"
+	if spec.Script != "" {
+		ext = "json"
+	}
+	data, fetchedAt, err := fetchPayload(pageURL, ext, func() ([]byte, error) {
+		return renderHeadless(ctx, pageURL, spec)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []scrapedEntry
+	if spec.Script != "" {
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, fmt.Errorf("bad script result: %v", err)
+		}
+	} else {
+		doc, err := goquery.NewDocumentFromReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		entries = selectEntries(doc, spec)
+	}
+
+	return stampReplayDates(entries, fetchedAt), nil
+}
+
+// stampReplayDates gives replayed entries without dates the time they were recorded
+func stampReplayDates(entries []scrapedEntry, recordedAt time.Time) []scrapedEntry {
+	if CollectorMode() == ModeReplay {
+		for i := range entries {
+			if entries[i].Date == "" {
+				entries[i].Date = recordedAt.Format(time.RFC3339)
+			}
+		}
+	}
+	return entries
+}
+
+// renderHeadless runs the page in the browser and returns the script's
+// entries as JSON, or the rendered HTML when there is no script
+func renderHeadless(ctx context.Context, pageURL string, spec ScrapeSpec) ([]byte, error) {
+	ctx, release, err := Browsers.Lease(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("headless fetch failed: %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	waitFor := spec.WaitFor
+	if waitFor == "" {
+		waitFor = "body"
+	}
+
+	actions := []chromedp.Action{
+		// Hide webdriver property
+		chromedp.ActionFunc(func(ctx context.Context) error {
+			return chromedp.Evaluate(`Object.defineProperty(navigator, 'webdriver', {get: () => undefined})`, nil).Do(ctx)
+		}),
+		chromedp.Navigate(pageURL),
+		chromedp.WaitVisible(waitFor, chromedp.ByQuery),
+	}
+	if settle, err := time.ParseDuration(spec.Settle); err == nil && settle > 0 {
+		actions = append(actions, chromedp.Sleep(settle))
+	}
+
+	var entries []scrapedEntry
+	var html string
+	if spec.Script != "" {
+		actions = append(actions, chromedp.Evaluate(spec.Script, &entries))
+	} else {
+		actions = append(actions, chromedp.OuterHTML("html", &html, chromedp.ByQuery))
+	}
+
+	if err := chromedp.Run(ctx, actions...); err != nil {
+		return nil, fmt.Errorf("headless fetch failed: %v", err)
+	}
+
+	if spec.Script != "" {
+		return json.Marshal(entries)
+	}
+	return []byte(html), nil
+}
+
+// selectEntries applies the spec's CSS selectors to a parsed page
+func selectEntries(doc *goquery.Document, spec ScrapeSpec) []scrapedEntry {
+	var entries []scrapedEntry
+	doc.Find(spec.Items).Each(func(_ int, sel *goquery.Selection) {
+		var entry scrapedEntry
+
+		titleSel := sel
+		if spec.Title != "" {
+			titleSel = sel.Find(spec.Title).First()
+		}
+		entry.Title = collapseSpaces(titleSel.Text())
+
+		linkSel := sel
+		if spec.Link != "" {
+			linkSel = sel.Find(spec.Link).First()
+		}
+		entry.Link, _ = linkSel.Attr("href")
+
+		if spec.Date != "" {
+			dateSel := sel.Find(spec.Date).First()
+			if dt, ok := dateSel.Attr("datetime"); ok {
+				entry.Date = dt
+			} else {
+				entry.Date = collapseSpaces(dateSel.Text())
+			}
+		}
+		entries = append(entries, entry)
+	})
+	return entries
+}
+
+// entriesToItems cleans, dedups and limits raw entries into NewsItems
+func entriesToItems(entries []scrapedEntry, sourceName, pageURL string, spec ScrapeSpec) []NewsItem {
+	limit := spec.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+	base, _ := url.Parse(pageURL)
+
+	var items []NewsItem
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		if len(items) >= limit {
+			break
+		}
+
+		title := collapseSpaces(entry.Title)
+		if title == "" || len(title) < spec.MinTitleLength {
+			continue
+		}
+
+		link := strings.TrimSpace(entry.Link)
+		if link != "" && base != nil {
+			if ref, err := url.Parse(link); err == nil {
+				link = base.ResolveReference(ref).String()
+			}
+		}
+
+		id := CanonicalID(link, sourceName, title)
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		items = append(items, NewsItem{
+			ID:        id,
+			Title:     title,
+			Source:    sourceName,
+			Timestamp: parseScrapedDate(entry.Date, spec.DateLayout),
+			Link:      link,
+		})
+	}
+	return items
+}
+
+// parseScrapedDate tries the configured layout, then common formats, then falls back to now
+func parseScrapedDate(value, layout string) time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Now()
+	}
+
+	layouts := []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02", "Jan 2, 2006", "January 2, 2006", "02 Jan 2006"}
+	if layout != "" {
+		layouts = append([]string{layout}, layouts...)
+	}
+	for _, l := range layouts {
+		if t, err := time.Parse(l, value); err == nil {
+			return t
+		}
+	}
+	return time.Now()
+}
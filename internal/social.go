@@ -0,0 +1,212 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// socialUserAgent identifies us; Reddit throttles generic browser agents hard
+const socialUserAgent = "crypto-news-intelligence/1.0 (news monitor)"
+
+// redditSource reads the public listing JSON of one or more subreddits
+type redditSource struct {
+	name       string
+	subreddits []string
+	sort       string
+	minScore   int
+	limit      int
+}
+
+func newRedditSource(cfg SourceConfig) (Source, error) {
+	if len(cfg.Subreddits) == 0 {
+		return nil, fmt.Errorf("reddit source %s needs subreddits", cfg.Name)
+	}
+	sort := cfg.Sort
+	if sort == "" {
+		sort = "new"
+	}
+	if sort != "new" && sort != "hot" && sort != "rising" && sort != "top" {
+		return nil, fmt.Errorf("reddit source %s: unknown sort %q", cfg.Name, sort)
+	}
+	return &redditSource{
+		name:       cfg.Name,
+		subreddits: cfg.Subreddits,
+		sort:       sort,
+		minScore:   cfg.MinScore,
+		limit:      25,
+	}, nil
+}
+
+func (s *redditSource) Name() string { return s.name }
+func (s *redditSource) Kind() string { return KindReddit }
+
+// redditListing is the part of /r/<subs>/<sort>.json we use
+type redditListing struct {
+	Data struct {
+		Children []struct {
+			Data struct {
+				ID          string  `json:"id"`
+				Title       string  `json:"title"`
+				Permalink   string  `json:"permalink"`
+				SelfText    string  `json:"selftext"`
+				Subreddit   string  `json:"subreddit"`
+				CreatedUTC  float64 `json:"created_utc"`
+				Score       int     `json:"score"`
+				NumComments int     `json:"num_comments"`
+				Stickied    bool    `json:"stickied"`
+				Over18      bool    `json:"over_18"`
+			} `json:"data"`
+		} `json:"children"`
+	} `json:"data"`
+}
+
+func (s *redditSource) Fetch(ctx context.Context) ([]NewsItem, error) {
+	// "A+B" fetches a combined listing in one request
+	listURL := fmt.Sprintf("https://www.reddit.com/r/%s/%s.json?limit=%d&raw_json=1",
+		strings.Join(s.subreddits, "+"), s.sort, s.limit)
+
+	var listing redditListing
+	if _, err := getSocialJSON(ctx, listURL, &listing); err != nil {
+		return nil, fmt.Errorf("reddit: %v", err)
+	}
+
+	var items []NewsItem
+	for _, child := range listing.Data.Children {
+		post := child.Data
+		title := collapseSpaces(post.Title)
+		if title == "" || post.Stickied || post.Over18 || post.Score < s.minScore {
+			continue
+		}
+
+		link := "https://www.reddit.com" + post.Permalink
+		items = append(items, NewsItem{
+			ID:          CanonicalID(link, s.name, title),
+			Title:       title,
+			Source:      s.name,
+			Timestamp:   time.Unix(int64(post.CreatedUTC), 0),
+			Link:        link,
+			Description: truncate(collapseSpaces(post.SelfText), 1000),
+			Social:      true,
+			Community:   "r/" + post.Subreddit,
+			Upvotes:     post.Score,
+			Comments:    post.NumComments,
+		})
+	}
+	return items, nil
+}
+
+// discourseSource reads /latest.json of a Discourse forum
+type discourseSource struct {
+	name     string
+	base     string
+	minScore int
+	limit    int
+}
+
+func newDiscourseSource(cfg SourceConfig) (Source, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("discourse source %s has no url", cfg.Name)
+	}
+	if _, err := url.Parse(cfg.URL); err != nil {
+		return nil, fmt.Errorf("discourse source %s: bad url: %v", cfg.Name, err)
+	}
+	return &discourseSource{
+		name:     cfg.Name,
+		base:     strings.TrimRight(cfg.URL, "/"),
+		minScore: cfg.MinScore,
+		limit:    25,
+	}, nil
+}
+
+func (s *discourseSource) Name() string { return s.name }
+func (s *discourseSource) Kind() string { return KindDiscourse }
+
+// discourseLatest is the part of /latest.json we use
+type discourseLatest struct {
+	TopicList struct {
+		Topics []struct {
+			ID         int       `json:"id"`
+			Title      string    `json:"title"`
+			Slug       string    `json:"slug"`
+			CreatedAt  time.Time `json:"created_at"`
+			LikeCount  int       `json:"like_count"`
+			PostsCount int       `json:"posts_count"`
+			Pinned     bool      `json:"pinned"`
+		} `json:"topics"`
+	} `json:"topic_list"`
+}
+
+func (s *discourseSource) Fetch(ctx context.Context) ([]NewsItem, error) {
+	var latest discourseLatest
+	fetchedAt, err := getSocialJSON(ctx, s.base+"/latest.json", &latest)
+	if err != nil {
+		return nil, fmt.Errorf("discourse: %v", err)
+	}
+
+	var items []NewsItem
+	for _, topic := range latest.TopicList.Topics {
+		if len(items) >= s.limit {
+			break
+		}
+		title := collapseSpaces(topic.Title)
+		if title == "" || topic.Pinned || topic.LikeCount < s.minScore {
+			continue
+		}
+
+		link := s.base + "/t/" + topic.Slug + "/" + strconv.Itoa(topic.ID)
+		ts := topic.CreatedAt
+		if ts.IsZero() {
+			ts = fetchedAt
+		}
+		items = append(items, NewsItem{
+			ID:        CanonicalID(link, s.name, title),
+			Title:     title,
+			Source:    s.name,
+			Timestamp: ts,
+			Link:      link,
+			Social:    true,
+			Community: s.name,
+			Upvotes:   topic.LikeCount,
+			Comments:  topic.PostsCount - 1, // The first post is the topic itself
+		})
+	}
+	return items, nil
+}
+
+// getSocialJSON GETs a community API endpoint and decodes the response into
+// v, returning when it was fetched (the recording time in replay mode)
+func getSocialJSON(ctx context.Context, endpoint string, v interface{}) (time.Time, error) {
+	data, fetchedAt, err := fetchPayload(endpoint, "json", func() ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("User-Agent", socialUserAgent)
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := feedClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("returned status: %d", resp.StatusCode)
+		}
+		return io.ReadAll(io.LimitReader(resp.Body, maxArticleBytes))
+	})
+	if err != nil {
+		return fetchedAt, err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fetchedAt, fmt.Errorf("decode failed: %v", err)
+	}
+	return fetchedAt, nil
+}
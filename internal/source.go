@@ -0,0 +1,233 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// Source kinds understood by the registry
+const (
+	KindRSS        = "rss"
+	KindHeadless   = "headless"
+	KindBinanceAPI = "binance-bapi"
+	KindHTML       = "html"
+	KindJSON       = "json"
+	KindJSONAPI    = "json-api" // Alias of KindJSON
+	KindReddit     = "reddit"
+	KindDiscourse  = "discourse"
+	KindGitHub     = "github"
+	KindTelegram   = "telegram"
+)
+
+// Source is anything the background scraper can poll for news
+type Source interface {
+	Name() string
+	Kind() string
+	Fetch(ctx context.Context) ([]NewsItem, error)
+}
+
+// Enricher is implemented by sources that can add detail to an item once it is
+// known to be new (e.g. downloading the full article), so the cost is paid once.
+type Enricher interface {
+	Enrich(ctx context.Context, item *NewsItem) error
+}
+
+// SourceConfig describes a single source and how to build it
+type SourceConfig struct {
+	Name     string  `json:"name"`
+	Type     string  `json:"type"`
+	URL      string  `json:"url,omitempty"`
+	Interval string  `json:"interval,omitempty"` // e.g. "30s", "5m"
+	Trust    float64 `json:"trust,omitempty"`    // 0 = use scorer defaults
+	Enabled  *bool   `json:"enabled,omitempty"`  // nil = enabled
+	Fallback string  `json:"fallback,omitempty"` // source type to try when this one fails
+
+	// Scheduling
+	Timeout    string `json:"timeout,omitempty"`     // per-fetch deadline, default 90s
+	MaxBackoff string `json:"max_backoff,omitempty"` // cap for failure backoff, default 10m
+
+	// Content
+	ExtractBody bool        `json:"extract_body,omitempty"` // fetch the article page for new items
+	Scrape      *ScrapeSpec `json:"scrape,omitempty"`       // selectors for html / headless sources
+
+	// Request (json sources)
+	Method  string            `json:"method,omitempty"` // default GET
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+	JSON    *JSONSpec         `json:"json,omitempty"` // item path + field mappings
+
+	// Community (reddit / discourse sources)
+	Subreddits []string `json:"subreddits,omitempty"` // e.g. ["CryptoCurrency", "Bitcoin"]
+	Sort       string   `json:"sort,omitempty"`       // reddit listing: new (default), hot, rising, top
+	MinScore   int      `json:"min_score,omitempty"`  // skip posts with fewer upvotes / likes
+
+	// Protocol repos (github sources): "owner/repo" -> asset, e.g. {"ethereum/go-ethereum": "ETH"}
+	Repos map[string]string `json:"repos,omitempty"`
+
+	// Public channels (telegram sources), e.g. ["binance_announcements"]
+	Channels []string `json:"channels,omitempty"`
+}
+
+// IsEnabled reports whether the source should be polled
+func (c SourceConfig) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
+
+// PollInterval returns the configured interval or the global default
+func (c SourceConfig) PollInterval() time.Duration {
+	if d, err := time.ParseDuration(c.Interval); err == nil && d > 0 {
+		return d
+	}
+	return DefaultPollInterval()
+}
+
+// DefaultPollInterval reads SCRAPE_INTERVAL, falling back to 10s
+func DefaultPollInterval() time.Duration {
+	if d, err := time.ParseDuration(os.Getenv("SCRAPE_INTERVAL")); err == nil && d > 0 {
+		return d
+	}
+	return 10 * time.Second
+}
+
+// SourceFactory builds a Source from its config
+type SourceFactory func(cfg SourceConfig) (Source, error)
+
+// sourceEntry pairs a live source with the config it was built from
+type sourceEntry struct {
+	src Source
+	cfg SourceConfig
+}
+
+var (
+	registryMu  sync.RWMutex
+	sourceKinds = make(map[string]SourceFactory)
+	sources     []sourceEntry
+)
+
+// RegisterSourceKind makes a new source type available to NewSource.
+// Collectors call this from init().
+func RegisterSourceKind(kind string, factory SourceFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	sourceKinds[kind] = factory
+}
+
+// NewSource builds a Source using the factory registered for cfg.Type
+func NewSource(cfg SourceConfig) (Source, error) {
+	registryMu.RLock()
+	factory, ok := sourceKinds[cfg.Type]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown source type %q for %s", cfg.Type, cfg.Name)
+	}
+
+	primary, err := factory(cfg)
+	if err != nil || cfg.Fallback == "" {
+		return primary, err
+	}
+
+	backupCfg := cfg
+	backupCfg.Type, backupCfg.Fallback = cfg.Fallback, ""
+	backup, err := NewSource(backupCfg)
+	if err != nil {
+		return nil, fmt.Errorf("fallback for %s: %v", cfg.Name, err)
+	}
+	return &fallbackSource{primary: primary, backup: backup}, nil
+}
+
+// fallbackSource tries its primary collector first and the backup on failure
+type fallbackSource struct {
+	primary Source
+	backup  Source
+}
+
+func (s *fallbackSource) Name() string { return s.primary.Name() }
+func (s *fallbackSource) Kind() string { return s.primary.Kind() }
+
+func (s *fallbackSource) Fetch(ctx context.Context) ([]NewsItem, error) {
+	items, err := s.primary.Fetch(ctx)
+	if err == nil {
+		return items, nil
+	}
+	log.Printf("↪️  %s: %s failed (%v), falling back to %s", s.Name(), s.primary.Kind(), err, s.backup.Kind())
+	return s.backup.Fetch(ctx)
+}
+
+// SetSources builds every enabled config and swaps the registry in one go.
+// Broken entries are logged and skipped so one typo cannot take down the rest.
+func SetSources(cfgs []SourceConfig) int {
+	var entries []sourceEntry
+	seen := make(map[string]bool)
+	for _, cfg := range cfgs {
+		if !cfg.IsEnabled() {
+			continue
+		}
+		if seen[cfg.Name] {
+			log.Printf("⚠️  Skipping duplicate source name %q", cfg.Name)
+			continue
+		}
+		src, err := NewSource(cfg)
+		if err != nil {
+			log.Printf("⚠️  Skipping source %s: %v", cfg.Name, err)
+			continue
+		}
+		seen[cfg.Name] = true
+		entries = append(entries, sourceEntry{src: src, cfg: cfg})
+	}
+
+	registryMu.Lock()
+	sources = entries
+	registryMu.Unlock()
+	return len(entries)
+}
+
+// Sources returns a snapshot of all registered sources
+func Sources() []Source {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	list := make([]Source, 0, len(sources))
+	for _, e := range sources {
+		list = append(list, e.src)
+	}
+	return list
+}
+
+// SourceConfigs returns the configs of all registered sources
+func SourceConfigs() []SourceConfig {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	list := make([]SourceConfig, 0, len(sources))
+	for _, e := range sources {
+		list = append(list, e.cfg)
+	}
+	return list
+}
+
+// SourceConfigFor returns the config a registered source was built from
+func SourceConfigFor(name string) (SourceConfig, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for _, e := range sources {
+		if e.src.Name() == name {
+			return e.cfg, true
+		}
+	}
+	return SourceConfig{}, false
+}
+
+// DefaultSourceConfigs is the built-in source list
+func DefaultSourceConfigs() []SourceConfig {
+	return []SourceConfig{
+		{Name: "Binance Announcements", Type: KindBinanceAPI, Fallback: KindHeadless},
+		{Name: "CoinDesk", Type: KindRSS, URL: "https://www.coindesk.com/arc/outboundfeeds/rss/"},
+		{Name: "CoinTelegraph", Type: KindRSS, URL: "https://cointelegraph.com/rss"},
+		{Name: "Decrypt", Type: KindRSS, URL: "https://decrypt.co/feed"},
+	}
+}
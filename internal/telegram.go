@@ -0,0 +1,144 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// telegramSource reads the public web preview (t.me/s/<channel>) of channels
+type telegramSource struct {
+	name     string
+	channels []string
+	limit    int // Newest messages per channel
+}
+
+func newTelegramSource(cfg SourceConfig) (Source, error) {
+	if len(cfg.Channels) == 0 {
+		return nil, fmt.Errorf("telegram source %s needs channels", cfg.Name)
+	}
+	var channels []string
+	for _, ch := range cfg.Channels {
+		ch = strings.TrimPrefix(strings.TrimSpace(ch), "@")
+		if ch == "" || strings.ContainsAny(ch, "/?# ") {
+			return nil, fmt.Errorf("telegram source %s: bad channel %q", cfg.Name, ch)
+		}
+		channels = append(channels, ch)
+	}
+	return &telegramSource{name: cfg.Name, channels: channels, limit: 10}, nil
+}
+
+func (s *telegramSource) Name() string { return s.name }
+func (s *telegramSource) Kind() string { return KindTelegram }
+
+// Fetch reads every channel; one broken channel doesn't fail the others
+func (s *telegramSource) Fetch(ctx context.Context) ([]NewsItem, error) {
+	var items []NewsItem
+	var firstErr error
+	for _, ch := range s.channels {
+		msgs, err := FetchTelegramChannel(ctx, ch, s.limit)
+		if err != nil {
+			log.Printf("⚠️  Telegram @%s: %v", ch, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		items = append(items, msgs...)
+	}
+
+	if len(items) == 0 && firstErr != nil {
+		return nil, firstErr
+	}
+	return items, nil
+}
+
+// FetchTelegramChannel scrapes the newest messages of a public channel.
+// Items use the channel's display name as Source.
+func FetchTelegramChannel(ctx context.Context, channel string, limit int) ([]NewsItem, error) {
+	pageURL := "https://t.me/s/" + channel
+	data, fetchedAt, err := fetchPayload(pageURL, "html", func() ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+
+		resp, err := feedClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("channel page returned status: %d", resp.StatusCode)
+		}
+		return io.ReadAll(io.LimitReader(resp.Body, maxArticleBytes))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	source := collapseSpaces(doc.Find(".tgme_channel_info_header_title").First().Text())
+	if source == "" {
+		source = "@" + channel
+	}
+
+	// Messages are listed oldest first
+	messages := doc.Find(".tgme_widget_message[data-post]")
+	var items []NewsItem
+	for i := messages.Length() - 1; i >= 0 && len(items) < limit; i-- {
+		msg := messages.Eq(i)
+
+		textSel := msg.Find(".tgme_widget_message_text").First()
+		textSel.Find("br").ReplaceWithHtml("\n")
+		text := strings.TrimSpace(textSel.Text())
+		if text == "" {
+			continue // Photo / sticker only
+		}
+
+		post, _ := msg.Attr("data-post") // "channel/123"
+		link := "https://t.me/" + post
+
+		ts := fetchedAt
+		if dt, ok := msg.Find("time[datetime]").First().Attr("datetime"); ok {
+			if parsed, err := time.Parse(time.RFC3339, dt); err == nil {
+				ts = parsed
+			}
+		}
+
+		title := messageTitle(text)
+		items = append(items, NewsItem{
+			ID:          CanonicalID(link, source, title),
+			Title:       title,
+			Source:      source,
+			Timestamp:   ts,
+			Link:        link,
+			Description: truncate(collapseSpaces(text), 1000),
+		})
+	}
+	return items, nil
+}
+
+// messageTitle turns a chat message into a headline: its first non-empty
+// line, capped at 200 characters
+func messageTitle(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		if line = collapseSpaces(line); line != "" {
+			return truncate(line, 200)
+		}
+	}
+	return truncate(collapseSpaces(text), 200)
+}
@@ -0,0 +1,226 @@
+package internal
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Token is one word of a text; Start/End are byte offsets into the original
+type Token struct {
+	Text  string // As written, possessive "'s" dropped
+	Word  string // Lowercased Text
+	Stem  string // See stem
+	Start int
+	End   int
+}
+
+// TokenizedText is a text split into words for keyword and phrase matching
+type TokenizedText struct {
+	Raw    string
+	Tokens []Token
+}
+
+// Tokenize splits text on Unicode word boundaries: runs of letters, digits and
+// combining marks, with apostrophes kept inside words ("don't"). Everything
+// else (spaces, punctuation, "$", "-", "/") separates words.
+func Tokenize(text string) *TokenizedText {
+	t := &TokenizedText{Raw: text}
+	start := -1
+	for i := 0; i <= len(text); {
+		r, size := rune(0), 1
+		if i < len(text) {
+			r, size = utf8.DecodeRuneInString(text[i:])
+		}
+
+		inWord := i < len(text) && isWordRune(r)
+		if !inWord && start >= 0 && isApostrophe(r) {
+			// Apostrophe between two word runes stays in the word
+			if next, _ := utf8.DecodeRuneInString(text[i+size:]); i+size < len(text) && isWordRune(next) {
+				inWord = true
+			}
+		}
+
+		switch {
+		case inWord && start < 0:
+			start = i
+		case !inWord && start >= 0:
+			t.Tokens = append(t.Tokens, newToken(text[start:i], start, i))
+			start = -1
+		}
+		i += size
+	}
+	return t
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)
+}
+
+func isApostrophe(r rune) bool {
+	return r == '\'' || r == '’'
+}
+
+func newToken(raw string, start, end int) Token {
+	text := strings.ReplaceAll(raw, "’", "'")
+	if n := len(text); n > 2 && strings.EqualFold(text[n-2:], "'s") {
+		text = text[:n-2]
+	}
+	word := strings.ToLower(text)
+	return Token{Text: text, Word: word, Stem: stem(word), Start: start, End: end}
+}
+
+// stem strips common English inflections so "surge", "surges" and "surged"
+// (or "list", "listings" and "listed") compare equal. Deliberately light: it
+// only has to make a headline and a lexicon term agree, not produce real
+// roots. Plurals go first so "listings" reaches "list" like "listing" does.
+func stem(word string) string {
+	if len(word) <= 3 || !isASCIIWord(word) || invariantWords[word] {
+		return word
+	}
+
+	// Keep at least three letters so "fed", "bring" or "uses" stay words
+	cut := func(w, suffix string) (string, bool) {
+		if strings.HasSuffix(w, suffix) && len(w)-len(suffix) >= 3 {
+			return strings.TrimSuffix(w, suffix), true
+		}
+		return w, false
+	}
+
+	// 1. Plural / third person: rallies -> rally, crashes -> crash, surges -> surge
+	w := word
+	if s, ok := cut(w, "ies"); ok {
+		w = s + "y"
+	} else if s, ok := cut(w, "es"); ok && (strings.HasSuffix(s, "ss") || strings.HasSuffix(s, "sh") ||
+		strings.HasSuffix(s, "ch") || strings.HasSuffix(s, "x") || strings.HasSuffix(s, "z")) {
+		w = s
+	} else if !strings.HasSuffix(w, "ss") && !strings.HasSuffix(w, "us") && !strings.HasSuffix(w, "is") {
+		w, _ = cut(w, "s")
+	}
+
+	// 2. Past tense / progressive: rallied -> rally, listing -> list, dropped -> drop
+	if s, ok := cut(w, "ied"); ok {
+		w = s + "y"
+	} else if s, ok := cut(w, "ing"); ok {
+		w = undouble(s)
+	} else if s, ok := cut(w, "ed"); ok && !strings.HasSuffix(w, "eed") {
+		w = undouble(s)
+	}
+
+	// 3. Silent e, so "surge" meets "surged": surge -> surg
+	w, _ = cut(w, "e")
+	return w
+}
+
+// invariantWords end like inflections but aren't ("news" is not "new" + s)
+var invariantWords = map[string]bool{
+	"news": true, "bias": true, "plus": true, "always": true, "perhaps": true, "series": true,
+	"alias": true, "atlas": true, "canvas": true, "chaos": true, "lens": true, "whereas": true,
+}
+
+// undouble turns "dropp" (from "dropped") back into "drop"; l, s and z
+// double in the base word too ("fall", "pass", "buzz")
+func undouble(s string) string {
+	n := len(s)
+	if n >= 4 && s[n-1] == s[n-2] && !strings.ContainsRune("aeioulsz", rune(s[n-1])) {
+		return s[:n-1]
+	}
+	return s
+}
+
+func isASCIIWord(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// Find returns the token index of every place term appears, comparing stems,
+// so multi-word terms ("interest rate") match as consecutive words
+func (t *TokenizedText) Find(term string) []int {
+	return t.find(term, func(tok Token) string { return tok.Stem })
+}
+
+// Has reports whether term appears anywhere (see Find)
+func (t *TokenizedText) Has(term string) bool {
+	return len(t.Find(term)) > 0
+}
+
+// HasAny reports whether any of the terms appears (see Find)
+func (t *TokenizedText) HasAny(terms []string) bool {
+	for _, term := range terms {
+		if t.Has(term) {
+			return true
+		}
+	}
+	return false
+}
+
+// WordIndex returns the byte offset of the first exact, unstemmed match of
+// term, or -1. Names are matched this way: "tons" must not mean TON.
+func (t *TokenizedText) WordIndex(term string) int {
+	if at := t.find(term, func(tok Token) string { return tok.Word }); len(at) > 0 {
+		return t.Tokens[at[0]].Start
+	}
+	return -1
+}
+
+// CaseIndex is WordIndex, but case-sensitive: "Render" does not match "render"
+func (t *TokenizedText) CaseIndex(term string) int {
+	if at := t.find(term, func(tok Token) string { return tok.Text }); len(at) > 0 {
+		return t.Tokens[at[0]].Start
+	}
+	return -1
+}
+
+// WordSpans returns the byte range [start, end) of every match WordIndex accepts
+func (t *TokenizedText) WordSpans(term string) [][2]int {
+	return t.spans(term, func(tok Token) string { return tok.Word })
+}
+
+// CaseSpans returns the byte range of every match CaseIndex accepts
+func (t *TokenizedText) CaseSpans(term string) [][2]int {
+	return t.spans(term, func(tok Token) string { return tok.Text })
+}
+
+func (t *TokenizedText) spans(term string, key func(Token) string) [][2]int {
+	n := len(Tokenize(term).Tokens)
+	var spans [][2]int
+	for _, i := range t.find(term, key) {
+		spans = append(spans, [2]int{t.Tokens[i].Start, t.Tokens[i+n-1].End})
+	}
+	return spans
+}
+
+func (t *TokenizedText) find(term string, key func(Token) string) []int {
+	phrase := Tokenize(term).Tokens
+	if len(phrase) == 0 {
+		return nil
+	}
+
+	var at []int
+	for i := 0; i+len(phrase) <= len(t.Tokens); i++ {
+		match := true
+		for j, p := range phrase {
+			if key(t.Tokens[i+j]) != key(p) {
+				match = false
+				break
+			}
+		}
+		if match {
+			at = append(at, i)
+		}
+	}
+	return at
+}
+
+// stemKey identifies a term by its stems: "listing" and "listed" share one
+func stemKey(term string) string {
+	var stems []string
+	for _, tok := range Tokenize(term).Tokens {
+		stems = append(stems, tok.Stem)
+	}
+	return strings.Join(stems, " ")
+}
@@ -0,0 +1,88 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStem(t *testing.T) {
+	groups := [][]string{
+		{"list", "lists", "listing", "listings", "listed"},
+		{"surge", "surges", "surged", "surging"},
+		{"rally", "rallies", "rallied", "rallying"},
+		{"crash", "crashes", "crashed"},
+		{"drop", "drops", "dropped", "dropping"},
+		{"fall", "falls", "falling"},
+		{"lose", "loses", "losing"},
+		{"ban", "bans", "banned"},
+		{"release", "releases", "released"},
+		{"etf", "etfs"},
+	}
+	for _, g := range groups {
+		want := stem(g[0])
+		for _, w := range g[1:] {
+			if got := stem(w); got != want {
+				t.Errorf("stem(%q) = %q, want %q (as %q)", w, got, want, g[0])
+			}
+		}
+	}
+
+	// Words that only look inflected stay whole
+	exact := map[string]string{
+		"news":    "news",
+		"uses":    "use",
+		"fed":     "fed",
+		"status":  "status",
+		"process": "process",
+		"speed":   "speed",
+		"this":    "this",
+		"bring":   "bring",
+	}
+	for w, want := range exact {
+		if got := stem(w); got != want {
+			t.Errorf("stem(%q) = %q, want %q", w, got, want)
+		}
+	}
+}
+
+func TestTokenizedTextFind(t *testing.T) {
+	tests := []struct {
+		text string
+		term string
+		want []int
+	}{
+		{"New listings on Binance", "listing", []int{1}},
+		{"Binance listed PEPE after listing BONK", "listing", []int{1, 4}},
+		{"Etherscan adds ether tracking", "ether", []int{2}},
+		{"Ethereum's ether rallies", "ethereum", []int{0}},
+		{"Security update for SEC filings", "sec", []int{3}},
+		{"Fed holds interest rates steady", "interest rate", []int{2}},
+		{"Interest in rates", "interest rate", nil},
+		{"Bitcoin fails to break out", "break out", []int{3}},
+		{"Bitcoin breakout", "break out", nil},
+		{"Биткоин растёт, bitcoin surges", "surge", []int{3}},
+		{"News: nothing new", "new", []int{2}},
+		{"Don't panic", "don't", []int{0}},
+	}
+	for _, tt := range tests {
+		if got := Tokenize(tt.text).Find(tt.term); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Find(%q) in %q = %v, want %v", tt.term, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestTokenizedTextWordIndex(t *testing.T) {
+	text := Tokenize("Tons of users bridge to TON, Render renders")
+	if i := text.WordIndex("ton"); i != 24 {
+		t.Errorf(`WordIndex("ton") = %d, want 24`, i)
+	}
+	if i := text.WordIndex("user"); i != -1 {
+		t.Errorf(`WordIndex("user") = %d, want -1 (no stemming)`, i)
+	}
+	if i := text.CaseIndex("Render"); i != 29 {
+		t.Errorf(`CaseIndex("Render") = %d, want 29`, i)
+	}
+	if i := text.CaseIndex("TONS"); i != -1 {
+		t.Errorf(`CaseIndex("TONS") = %d, want -1`, i)
+	}
+}
@@ -1,14 +1,19 @@
 package main
 
 import (
+	"context"
 	"crypto-news-intelligence/internal"
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -19,44 +24,94 @@ type NewsStore struct {
 	sync.RWMutex
 	Items       []internal.NewsItem
 	MarketState internal.MarketState
-	SeenIDs     map[string]bool
 }
 
 var store = &NewsStore{
-	Items:   []internal.NewsItem{},
-	SeenIDs: make(map[string]bool),
+	Items: []internal.NewsItem{},
 }
 
+// Near-duplicate story clustering (24h window, 65% title overlap)
+var stories = internal.NewStoryClusterer(24*time.Hour, 0.65)
+
+// Headline translation on the poll and ingest paths runs a few items at a
+// time under one deadline per batch, so a slow LLM can't stall a poller;
+// items still waiting when it passes are analyzed untranslated
+const (
+	translateBudget   = 30 * time.Second
+	translateParallel = 4
+)
+
+var (
+	sourcesPath = internal.SourcesConfigPath()
+	scheduler   = internal.NewScheduler(handleFetchedItems)
+)
+
 func main() {
 	// Load .env file
 	if err := godotenv.Load(); err != nil {
 		log.Println("Note: No .env file found, relying on system environment variables")
 	}
+	sourcesPath = internal.SourcesConfigPath()
+
+	// CLI Commands (e.g. `server opml import feeds.opml`)
+	if len(os.Args) > 1 {
+		if err := runCommand(os.Args[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 
 	fmt.Println("🚀 Crypto News Intelligence Engine (Server Mode) Starting...")
 	fmt.Println("🌍 API Server running on http://localhost:8081")
-	fmt.Println("📡 Scraper running in background (10s interval)...")
+	fmt.Println("📡 Scraper running in background (per-source intervals)...")
+	if mode := internal.CollectorMode(); mode != internal.ModeLive {
+		fmt.Printf("📼 Collector mode: %s (fixtures in %s)\n", mode, internal.FixturesDir())
+	}
 	fmt.Println("==================================================")
 
 	// 1. Initialize Database
 	internal.InitDB()
 
+	internal.LoadSourceHealth()
+
+	// Analyzer word lists & weights (hot reload on SIGHUP or file change)
+	if err := internal.ReloadLexicon(internal.LexiconPath()); err != nil {
+		log.Fatal("Failed to load lexicon: ", err)
+	}
+	if err := internal.ReloadAssetRegistry(internal.AssetRegistryPath()); err != nil {
+		log.Fatal("Failed to load asset registry: ", err)
+	}
+
 	// 2. Load History from DB
 	history := internal.GetLatestNews(100)
 	store.Lock()
+	for i := len(history) - 1; i >= 0; i-- {
+		stories.Restore(history[i]) // Oldest first so canonicals come before duplicates
+	}
 	for _, item := range history {
-		store.Items = append(store.Items, item)
-		store.SeenIDs[item.ID] = true
+		// The feed only shows one card per story
+		if item.StoryID == "" || item.StoryID == item.ID {
+			store.Items = append(store.Items, item)
+		}
 	}
 	fmt.Printf("📂 Loaded %d items from database.\n", len(store.Items))
 	store.Unlock()
 
-	// 3. Start Background Scraper
-	go runBackgroundScraper()
+	// 3. Load Sources Config (hot reload on SIGHUP or file change) & Start Background Scraper
+	if err := internal.ReloadSources(sourcesPath); err != nil {
+		log.Fatal("Failed to load sources: ", err)
+	}
+	scheduler.Sync()
+	go watchSourcesConfig()
 
 	// 4. Setup HTTP Server
 	http.HandleFunc("/api/news", handleGetNews)
 	http.HandleFunc("/api/market", handleGetMarket)
+	http.HandleFunc("/api/sources", handleGetSources)
+	http.HandleFunc("/api/stories", handleGetStories)
+	http.HandleFunc("/api/admin/opml", requireToken("ADMIN_TOKEN", handleOPML))
+	http.HandleFunc("/api/ingest", requireToken("INGEST_TOKEN", handleIngest))
+	http.HandleFunc("/api/ingest/discord", tokenFromQuery(requireToken("INGEST_TOKEN", handleDiscordIngest)))
 	
 	// Serve Static Dashboard (web folder)
 	fs := http.FileServer(http.Dir("./web"))
@@ -72,143 +127,325 @@ func main() {
 	log.Fatal(http.ListenAndServe(":"+port, nil))
 }
 
-func runBackgroundScraper() {
-	feeds := map[string]string{
-		"Binance Announcements": "HEADLESS", // Special marker
-		"CoinDesk":              "https://www.coindesk.com/arc/outboundfeeds/rss/",
-		"CoinTelegraph":         "https://cointelegraph.com/rss",
-		"Decrypt":               "https://decrypt.co/feed",
+// runCommand handles the one-shot CLI commands
+func runCommand(args []string) error {
+	usage := fmt.Errorf("usage: server opml import <file.opml> | server opml export [file.opml] | server assets sync <exchangeInfo.json>")
+	if len(args) < 2 || (args[0] != "opml" && args[0] != "assets") {
+		return usage
 	}
 
-	for {
-		var wg sync.WaitGroup
-		resultsChan := make(chan internal.NewsItem, 100)
+	if args[0] == "assets" {
+		if args[1] != "sync" || len(args) < 3 {
+			return usage
+		}
+		f, err := os.Open(args[2])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
 
-		// Fetch
-		for name, url := range feeds {
-			wg.Add(1)
-			go func(sourceName, sourceUrl string) {
-				defer wg.Done()
-				var items []internal.NewsItem
-				var err error
+		path := internal.AssetRegistryPath()
+		added, err := internal.SyncAssetRegistry(path, f)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🪙 Added %d new assets to %s\n", added, path)
+		return nil
+	}
 
-				if sourceName == "Binance Announcements" {
-					// Use the new Headless Scraper
-					items, err = internal.FetchBinanceHeadless()
-				} else {
-					items, err = internal.FetchRSS(sourceUrl, sourceName)
-				}
+	switch args[1] {
+	case "import":
+		if len(args) < 3 {
+			return usage
+		}
+		f, err := os.Open(args[2])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
 
-				if err != nil {
-					// Filter out common noise
-					msg := err.Error()
-					if !strings.Contains(msg, "193") && !strings.Contains(msg, "timeout") {
-						log.Printf("⚠️  Error fetching from %s: %v", sourceName, err)
-					}
-					return
-				}
+		added, err := internal.ImportOPML(sourcesPath, f)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("📥 Imported %d new feeds into %s\n", added, sourcesPath)
+		return nil
 
-				for _, item := range items {
-					internal.AnalyzeNews(&item)
-					resultsChan <- item
-				}
-			}(name, url)
-		}
-
-		go func() {
-			wg.Wait()
-			close(resultsChan)
-		}()
-
-		// Process & Update Store
-		var newItems []internal.NewsItem
-		store.Lock()
-		for item := range resultsChan {
-			if !store.SeenIDs[item.ID] {
-				newItems = append(newItems, item)
-				store.SeenIDs[item.ID] = true
-				
-				// Persist to DB
-				internal.SaveNewsItem(item)
+	case "export":
+		cfgs, err := internal.ExportSourceConfigs(sourcesPath)
+		if err != nil {
+			return err
+		}
+		out := os.Stdout
+		if len(args) > 2 {
+			if out, err = os.Create(args[2]); err != nil {
+				return err
 			}
+			defer out.Close()
 		}
-		
-		// Prepend new items to the list (newest first)
-		if len(newItems) > 0 {
-			// Update Market Context
-			store.MarketState = internal.CalculateMarketState(newItems)
-			
-			// Apply Rules & Calculate Score
-			for i := range newItems {
-				if newItems[i].Scope != "MARKET" {
-					internal.ApplyTradingRules(&newItems[i], store.MarketState)
-					newItems[i].FinalScore = internal.CalculateScore(newItems[i])
-					
-					// Update DB with Score/Signal
-					internal.SaveNewsItem(newItems[i])
-				}
+		return internal.WriteOPML(out, cfgs)
+	}
+	return usage
+}
+
+// reloadSources rebuilds the registry from disk and restarts the pollers
+func reloadSources(reason string) error {
+	log.Printf("🔄 Reloading sources (%s)...", reason)
+	if err := internal.ReloadSources(sourcesPath); err != nil {
+		log.Printf("⚠️  Sources reload failed, keeping current set: %v", err)
+		return err
+	}
+	scheduler.Sync()
+	return nil
+}
+
+func watchSourcesConfig() {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			reloadSources("SIGHUP")
+			reloadLexicon("SIGHUP")
+			reloadAssetRegistry("SIGHUP")
+		}
+	}()
+
+	go internal.WatchFile(internal.LexiconPath(), 5*time.Second, func() { reloadLexicon("file changed") })
+	go internal.WatchFile(internal.AssetRegistryPath(), 5*time.Second, func() { reloadAssetRegistry("file changed") })
+	internal.WatchFile(sourcesPath, 5*time.Second, func() { reloadSources("file changed") })
+}
+
+// reloadAssetRegistry swaps in the edited asset dictionary; a broken file keeps the old one
+func reloadAssetRegistry(reason string) {
+	log.Printf("🔄 Reloading asset registry (%s)...", reason)
+	if err := internal.ReloadAssetRegistry(internal.AssetRegistryPath()); err != nil {
+		log.Printf("⚠️  Asset registry reload failed, keeping current one: %v", err)
+	}
+}
+
+// reloadLexicon swaps in the edited lexicon; a broken file keeps the old one
+func reloadLexicon(reason string) {
+	log.Printf("🔄 Reloading lexicon (%s)...", reason)
+	if err := internal.ReloadLexicon(internal.LexiconPath()); err != nil {
+		log.Printf("⚠️  Lexicon reload failed, keeping current one: %v", err)
+	}
+}
+
+// handleFetchedItems is called by each source's poller as soon as its fetch
+// completes; ctx ends when the poller is stopped (e.g. by a config reload)
+func handleFetchedItems(ctx context.Context, src internal.Source, cfg internal.SourceConfig, items []internal.NewsItem) {
+	// Only pay for enrichment (article download) on items we haven't seen yet
+	var fresh, seen []internal.NewsItem
+	for _, item := range items {
+		if !internal.IsSeenItem(item) {
+			fresh = append(fresh, item)
+		} else if item.Social {
+			seen = append(seen, item)
+		}
+	}
+	refreshEngagement(seen)
+
+	enricher, canEnrich := src.(internal.Enricher)
+	for i := range fresh {
+		fresh[i].Trust = cfg.Trust
+		if canEnrich && ctx.Err() == nil {
+			ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+			if err := enricher.Enrich(ctx, &fresh[i]); err != nil {
+				log.Printf("⚠️  Could not enrich %s: %v", fresh[i].Link, err)
 			}
+			cancel()
+		}
+	}
+	prepareLanguages(ctx, fresh)
+	for i := range fresh {
+		internal.AnalyzeNews(&fresh[i])
+	}
+	ingestItems(fresh)
+}
+
+// prepareLanguages runs PrepareLanguage over a batch, translateParallel
+// items at a time, within translateBudget
+func prepareLanguages(ctx context.Context, items []internal.NewsItem) {
+	ctx, cancel := context.WithTimeout(ctx, translateBudget)
+	defer cancel()
+
+	slots := make(chan struct{}, translateParallel)
+	var wg sync.WaitGroup
+	for i := range items {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(item *internal.NewsItem) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			internal.PrepareLanguage(ctx, item)
+		}(&items[i])
+	}
+	wg.Wait()
+}
+
+// ingestItems merges analyzed items into the store, scores them and queues AI
+// analysis. It returns how many became new stories; items already seen or
+// folded into an existing story don't count.
+func ingestItems(items []internal.NewsItem) int {
+	// Process & Update Store
+	var newItems, duplicates []internal.NewsItem
+	store.Lock()
+	for _, item := range items {
+		// Persistent index: survives restarts and never forgets
+		if !internal.MarkSeen(item.ID) {
+			continue
+		}
+
+		// Same event from another outlet: fold it into the existing story
+		if _, dup := stories.Assign(&item); dup {
+			duplicates = append(duplicates, item)
+			continue
+		}
+		newItems = append(newItems, item)
+
+		// Persist to DB
+		internal.SaveNewsItem(item)
+	}
+
+	// Prepend new items to the list (newest first)
+	if len(newItems) > 0 {
+		// Update Market Context
+		store.MarketState = internal.CalculateMarketState(newItems)
+
+		// Apply Rules & Calculate Score
+		for i := range newItems {
+			if newItems[i].Scope != "MARKET" {
+				internal.ApplyTradingRules(&newItems[i], store.MarketState)
+				newItems[i].FinalScore = internal.CalculateScore(newItems[i])
+
+				// Update DB with Score/Signal
+				internal.SaveNewsItem(newItems[i])
+			}
+		}
+
+		store.Items = append(newItems, store.Items...)
+		// Keep only last 100 items to prevent memory bloat
+		if len(store.Items) > 100 {
+			store.Items = store.Items[:100]
+		}
+		fmt.Printf("✓ Synced %d new items.\n", len(newItems))
+	}
+
+	// Extra coverage raises the canonical item's confidence instead of adding cards
+	for _, dup := range duplicates {
+		internal.SaveNewsItem(dup)
+		var canonical *internal.NewsItem
+		for i := range store.Items {
+			if store.Items[i].ID == dup.StoryID {
+				canonical = &store.Items[i]
+				break
+			}
+		}
+		if canonical == nil {
+			// Older than the in-memory window: update the stored row
+			item, ok := internal.GetNewsItem(dup.StoryID)
+			if !ok {
+				continue
+			}
+			canonical = &item
+		}
+		canonical.Coverage = dup.Coverage
+		if canonical.Scope != "MARKET" {
+			if canonical.AIAnalysis == "" {
+				internal.ApplyTradingRules(canonical, store.MarketState)
+			}
+			canonical.FinalScore = internal.CalculateScore(*canonical)
+		}
+		internal.SaveNewsItem(*canonical)
+	}
+	if len(duplicates) > 0 {
+		fmt.Printf("🔗 Merged %d duplicate reports into existing stories.\n", len(duplicates))
+	}
+
+	store.Unlock()
+
+	// Async: Process AI for new items (Non-blocking)
+	if len(newItems) > 0 {
+		go runAIAnalysis(newItems)
+	}
+	return len(newItems)
+}
 
-			store.Items = append(newItems, store.Items...)
-			// Keep only last 100 items to prevent memory bloat
-			if len(store.Items) > 100 {
-				store.Items = store.Items[:100]
+// refreshEngagement copies the current upvotes and comments of social posts
+// seen on earlier polls and rescores them. A post is first fetched minutes
+// after it's made, so the counts from that poll say almost nothing.
+func refreshEngagement(items []internal.NewsItem) {
+	if len(items) == 0 {
+		return
+	}
+	store.Lock()
+	defer store.Unlock()
+
+	refreshed := 0
+	for _, fetched := range items {
+		var stored *internal.NewsItem
+		for i := range store.Items {
+			if store.Items[i].ID == fetched.ID {
+				stored = &store.Items[i]
+				break
+			}
+		}
+		if stored == nil {
+			// Older than the in-memory window: update the stored row
+			item, ok := internal.GetNewsItem(fetched.ID)
+			if !ok {
+				continue
 			}
-			fmt.Printf("✓ Synced %d new items.\n", len(newItems))
+			stored = &item
+		}
+		if stored.Upvotes == fetched.Upvotes && stored.Comments == fetched.Comments {
+			continue
 		}
 
-		// Memory Safety: Prevent SeenIDs from growing infinitely
-		if len(store.SeenIDs) > 5000 {
-			// Reset map and re-populate only with active items
-			newMap := make(map[string]bool)
-			for _, item := range store.Items {
-				newMap[item.ID] = true
+		stored.Upvotes, stored.Comments = fetched.Upvotes, fetched.Comments
+		if stored.Scope != "MARKET" {
+			stored.FinalScore = internal.CalculateScore(*stored)
+		}
+		internal.SaveNewsItem(*stored)
+		refreshed++
+	}
+	if refreshed > 0 {
+		fmt.Printf("📈 Refreshed engagement on %d posts.\n", refreshed)
+	}
+}
+
+func runAIAnalysis(items []internal.NewsItem) {
+	for _, item := range items {
+		// FORCE AI ON EVERYTHING FOR TESTING
+		// if item.FinalScore >= 0.05 || item.FinalScore <= -0.05 || item.TradingSignal == "STRONG_BUY" || item.Impact >= 0.7 {
+		if true {
+			fmt.Printf("🤖 Asking AI about: %s (Score: %.2f)...\n", item.Title, item.FinalScore)
+			ctx, advice, coin, signal := internal.AnalyzeNewsAI(item)
+
+			if ctx != "" {
+				fmt.Printf("✅ AI Insight Ready: %s (Coin: %s, Signal: %s)\n", item.Title, coin, signal)
 			}
-			store.SeenIDs = newMap
-			log.Println("🧹 Garbage Collection: Cleaned up old SeenIDs.")
-		}
-		
-		store.Unlock()
-
-		// Async: Process AI for new items (Non-blocking)
-		if len(newItems) > 0 {
-			go func(items []internal.NewsItem) {
-				for _, item := range items {
-					// FORCE AI ON EVERYTHING FOR TESTING
-					// if item.FinalScore >= 0.05 || item.FinalScore <= -0.05 || item.TradingSignal == "STRONG_BUY" || item.Impact >= 0.7 {
-					if true {
-						fmt.Printf("🤖 Asking AI about: %s (Score: %.2f)...\n", item.Title, item.FinalScore)
-						ctx, advice, coin, signal := internal.AnalyzeNewsAI(item)
-						
-						if ctx != "" {
-							fmt.Printf("✅ AI Insight Ready: %s (Coin: %s, Signal: %s)\n", item.Title, coin, signal)
-						}
-						
-						// Update Store Thread-Safely
-						store.Lock()
-						for i := range store.Items {
-							if store.Items[i].ID == item.ID {
-								store.Items[i].AIAnalysis = ctx
-								store.Items[i].AIAdvice = advice
-								store.Items[i].CoinSymbol = coin
-								
-								// OVERRIDE Signal with AI opinion if valid
-								if signal != "" && signal != "WAIT" {
-									store.Items[i].TradingSignal = signal
-								}
-								
-								// Update DB with AI results
-								internal.SaveNewsItem(store.Items[i])
-								break
-							}
-						}
-						store.Unlock()
+
+			// Update Store Thread-Safely
+			store.Lock()
+			for i := range store.Items {
+				if store.Items[i].ID == item.ID {
+					store.Items[i].AIAnalysis = ctx
+					store.Items[i].AIAdvice = advice
+					store.Items[i].CoinSymbol = coin
+
+					// OVERRIDE Signal with AI opinion if valid
+					if signal != "" && signal != "WAIT" {
+						store.Items[i].TradingSignal = signal
 					}
+
+					// Update DB with AI results
+					internal.SaveNewsItem(store.Items[i])
+					break
 				}
-			}(newItems)
+			}
+			store.Unlock()
 		}
-
-		time.Sleep(10 * time.Second)
 	}
 }
 
@@ -217,6 +454,20 @@ func handleGetNews(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	
+	// ?asset=BTC,ETH searches the full history for items tagged with any of them
+	if assets := r.URL.Query().Get("asset"); assets != "" {
+		limit := 100
+		if n, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && n > 0 && n <= 500 {
+			limit = n
+		}
+		items := internal.GetNewsByAsset(strings.Split(assets, ","), limit)
+		if items == nil {
+			items = []internal.NewsItem{}
+		}
+		json.NewEncoder(w).Encode(items)
+		return
+	}
+
 	store.RLock()
 	defer store.RUnlock()
 	
@@ -232,3 +483,156 @@ func handleGetMarket(w http.ResponseWriter, r *http.Request) {
 
 	json.NewEncoder(w).Encode(store.MarketState)
 }
+
+func handleGetSources(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	json.NewEncoder(w).Encode(internal.GetSourceStatuses())
+}
+
+// handleGetStories lists multi-source stories, or one story with ?id=
+func handleGetStories(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	if id := r.URL.Query().Get("id"); id != "" {
+		story, ok := stories.Get(id)
+		if !ok {
+			http.Error(w, "story not found", http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(story)
+		return
+	}
+
+	minSources := 2
+	if n, err := strconv.Atoi(r.URL.Query().Get("min_sources")); err == nil && n > 0 {
+		minSources = n
+	}
+	json.NewEncoder(w).Encode(stories.Stories(minSources))
+}
+
+// requireToken guards admin/ingest endpoints with a bearer token taken from
+// the given env var. If the variable is unset the endpoint stays disabled.
+func requireToken(envVar string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := os.Getenv(envVar)
+		if token == "" {
+			http.Error(w, envVar+" not configured", http.StatusForbidden)
+			return
+		}
+
+		given := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// handleIngest accepts pushed items (one object or an array) and runs them
+// through the same analysis, scoring and AI path as polled ones
+func handleIngest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	items, rejected, err := internal.ParseIngest(http.MaxBytesReader(w, r.Body, 1<<20))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	accepted := ingestPushed(r.Context(), items)
+
+	status := http.StatusAccepted
+	if len(items) == 0 {
+		status = http.StatusBadRequest
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"accepted":   accepted,
+		"duplicates": len(items) - accepted,
+		"rejected":   rejected,
+	})
+}
+
+// ingestPushed analyzes pushed items not seen before and ingests them,
+// returning how many were stored as new stories. Translation is bounded by
+// the request.
+func ingestPushed(ctx context.Context, items []internal.NewsItem) int {
+	var fresh []internal.NewsItem
+	for _, item := range items {
+		if !internal.IsSeenItem(item) {
+			fresh = append(fresh, item)
+		}
+	}
+	prepareLanguages(ctx, fresh)
+	for i := range fresh {
+		internal.AnalyzeNews(&fresh[i])
+	}
+	return ingestItems(fresh)
+}
+
+// tokenFromQuery lets senders that can't set headers (Discord webhooks) pass
+// the bearer token as ?token=
+func tokenFromQuery(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if token := r.URL.Query().Get("token"); token != "" && r.Header.Get("Authorization") == "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		next(w, r)
+	}
+}
+
+// handleDiscordIngest accepts Discord webhook payloads from a relay; the
+// channel name becomes the Source
+func handleDiscordIngest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	items, err := internal.ParseDiscordMessage(http.MaxBytesReader(w, r.Body, 1<<20), r.URL.Query().Get("channel"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ingestPushed(r.Context(), items)
+
+	// Discord expects 204 from a webhook
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleOPML exports the sources config (GET) or imports an OPML body (POST)
+func handleOPML(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		cfgs, err := internal.ExportSourceConfigs(sourcesPath)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/x-opml; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="sources.opml"`)
+		internal.WriteOPML(w, cfgs)
+
+	case http.MethodPost:
+		added, err := internal.ImportOPML(sourcesPath, http.MaxBytesReader(w, r.Body, 1<<20))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// The config watcher picks up the rewritten file and restarts the pollers
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]int{"imported": added})
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
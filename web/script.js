@@ -1,5 +1,6 @@
 const API_URL = "/api/news";
 const MARKET_URL = "/api/market";
+const SOURCES_URL = "/api/sources";
 
 let lastSeenId = "";
 let audioEnabled = false;
@@ -18,10 +19,12 @@ document.addEventListener("DOMContentLoaded", () => {
 
     fetchNews();
     fetchMarket();
+    fetchSources();
 
     // Poll faster for updates so AI appears quickly
     setInterval(fetchNews, 2000);
     setInterval(fetchMarket, 10000);
+    setInterval(fetchSources, 10000);
 });
 
 async function fetchNews() {
@@ -67,6 +70,55 @@ async function fetchMarket() {
     } catch (e) { }
 }
 
+async function fetchSources() {
+    try {
+        const res = await fetch(SOURCES_URL);
+        if (!res.ok) return;
+        const sources = await res.json();
+        renderSources(sources || []);
+    } catch (e) { }
+}
+
+function renderSources(sources) {
+    const container = document.getElementById("sources-container");
+    if (!container) return;
+
+    container.innerHTML = "";
+    sources.forEach(src => {
+        const row = document.createElement("div");
+        row.className = "source-row";
+
+        const dot = document.createElement("span");
+        dot.className = `source-dot ${src.Status.toLowerCase()}`;
+
+        const name = document.createElement("span");
+        name.className = "source-name";
+        name.innerText = src.Name;
+        row.title = src.LastError ? `${src.Status}: ${src.LastError}` : src.Status;
+
+        const meta = document.createElement("span");
+        meta.className = "source-meta";
+        meta.innerText = src.Status === "PENDING"
+            ? "--"
+            : `${timeAgo(src.LastSuccess)} • ${src.LastItemCount} • ${src.LastLatencyMs}ms`;
+
+        row.appendChild(dot);
+        row.appendChild(name);
+        row.appendChild(meta);
+        container.appendChild(row);
+    });
+}
+
+// "5m ago" style label; Go zero times mean "never"
+function timeAgo(ts) {
+    const t = new Date(ts).getTime();
+    if (!t || t < 0 || new Date(ts).getFullYear() < 2000) return "never";
+    const secs = Math.max(0, Math.floor((Date.now() - t) / 1000));
+    if (secs < 60) return `${secs}s ago`;
+    if (secs < 3600) return `${Math.floor(secs / 60)}m ago`;
+    return `${Math.floor(secs / 3600)}h ago`;
+}
+
 function renderNews(items) {
     const container = document.getElementById("news-container");
     if (!container) return;
@@ -88,8 +140,10 @@ function renderNews(items) {
         if (existingCard) {
             // Check if AI was missing but now exists
             const hasAI = existingCard.querySelector(".ai-box");
-            if (!hasAI && item.AIAnalysis) {
-                console.log("⚡ AI Update for:", item.Title);
+            const stateChanged = existingCard.dataset.state !== cardState(item);
+            if ((!hasAI && item.AIAnalysis) || stateChanged) {
+                console.log("⚡ Update for:", item.Title);
+                existingCard.dataset.state = cardState(item);
                 // Update logic: preserve the card but inject AI
                 // Or easier: replace innerHTML
                 existingCard.innerHTML = getCardHTML(item);
@@ -104,6 +158,7 @@ function renderNews(items) {
         // 2. CREATE NEW CARD
         const div = document.createElement("div");
         div.id = `news-card-${item.ID}`; // Unique ID for tracking
+        div.dataset.state = cardState(item);
 
         // Class logic
         const signal = item.TradingSignal || "";
@@ -125,6 +180,12 @@ function renderNews(items) {
     });
 }
 
+// Fields that can change after a card is shown: extra coverage, and the
+// engagement of social posts (re-read on later polls, which rescores them)
+function cardState(item) {
+    return [item.Coverage || 1, item.Upvotes || 0, item.Comments || 0, item.FinalScore || 0].join("|");
+}
+
 // Helper to generate inner content string
 function getCardHTML(item) {
     let assetDisplay = item.Asset || "GEN";
@@ -154,6 +215,13 @@ function getCardHTML(item) {
 
     const timeSpan = document.createElement("span");
     timeSpan.innerText = `${timeStr} • ${item.Source}`;
+    if (item.Coverage > 1) {
+        // Same story reported by other outlets
+        timeSpan.innerText += ` (+${item.Coverage - 1} sources)`;
+    }
+    if (item.Social) {
+        timeSpan.innerText += ` • ▲${item.Upvotes || 0} 💬${item.Comments || 0}`;
+    }
 
     const assetSpan = document.createElement("span");
     assetSpan.style.fontWeight = "bold";
@@ -166,6 +234,9 @@ function getCardHTML(item) {
     const titleDiv = document.createElement("div");
     titleDiv.className = "card-title";
     titleDiv.innerText = item.Title;
+    if (item.TitleEN) {
+        titleDiv.dir = "auto";
+    }
 
     // AI HTML is trusted (generated by our backend), but let's be safe.
     // For now, keep AI HTML as-is since it contains markup we might want (like bolding).
@@ -176,6 +247,24 @@ function getCardHTML(item) {
     wrapper.appendChild(metaDiv);
     wrapper.appendChild(titleDiv);
 
+    if (item.TitleEN) {
+        // Machine translation of a non-English headline
+        const enDiv = document.createElement("div");
+        enDiv.style.fontSize = "0.85rem";
+        enDiv.style.color = "var(--text-secondary)";
+        enDiv.innerText = `🌐 ${item.TitleEN}`;
+        wrapper.appendChild(enDiv);
+    }
+
+    if (item.SentimentNote) {
+        // Negation / hedging the analyzer accounted for
+        const noteDiv = document.createElement("div");
+        noteDiv.style.fontSize = "0.8rem";
+        noteDiv.style.color = "var(--text-secondary)";
+        noteDiv.innerText = `⚖️ ${item.SentimentNote}`;
+        wrapper.appendChild(noteDiv);
+    }
+
     if (aiHtml) {
         const aiDiv = document.createElement("div");
         aiDiv.innerHTML = aiHtml; // We trust our own AI output structure
@@ -195,11 +284,18 @@ function updateHeatmap(items) {
         }
         if (!sym) return;
 
-        if (!map[sym]) map[sym] = 0;
+        // Headline co-mentions count too (body-only mentions are too weak)
+        const symbols = new Set([sym]);
+        (item.Assets || []).forEach(tag => {
+            if (tag.Relevance >= 0.6) symbols.add(tag.Symbol);
+        });
 
         const signal = item.TradingSignal || "";
-        if (signal.includes("BUY")) map[sym]++;
-        if (signal.includes("SELL")) map[sym]--;
+        symbols.forEach(s => {
+            if (!map[s]) map[s] = 0;
+            if (signal.includes("BUY")) map[s]++;
+            if (signal.includes("SELL")) map[s]--;
+        });
     });
 
     const container = document.getElementById("heatmap-container");